@@ -5,6 +5,7 @@ package mocks
 import mock "github.com/stretchr/testify/mock"
 import sdk "github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 import subscribers "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+import websocket "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
 
 // CatapultClient is an autogenerated mock type for the CatapultClient type
 type CatapultClient struct {
@@ -52,6 +53,49 @@ func (_m *CatapultClient) AddConfirmedAddedHandlers(address *sdk.Address, handle
 	return r0
 }
 
+// AddConfirmedAddedHandlersForAddresses provides a mock function with given fields: addresses, handlers
+func (_m *CatapultClient) AddConfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, addresses)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+		r0 = rf(addresses, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddConfirmedAddedHandlersWithFilter provides a mock function with given fields: address, filter, handlers
+func (_m *CatapultClient) AddConfirmedAddedHandlersWithFilter(address *sdk.Address, filter websocket.TransactionFilter, handlers ...subscribers.ConfirmedAddedHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, address)
+	_ca = append(_ca, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.TransactionFilter, ...subscribers.ConfirmedAddedHandler) error); ok {
+		r0 = rf(address, filter, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddCosignatureHandlers provides a mock function with given fields: address, handlers
 func (_m *CatapultClient) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error {
 	_va := make([]interface{}, len(handlers))
@@ -157,6 +201,49 @@ func (_m *CatapultClient) AddStatusHandlers(address *sdk.Address, handlers ...su
 	return r0
 }
 
+// AddStatusHandlersForAddresses provides a mock function with given fields: addresses, handlers
+func (_m *CatapultClient) AddStatusHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.StatusHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, addresses)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*sdk.Address, ...subscribers.StatusHandler) error); ok {
+		r0 = rf(addresses, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTopicHandlers provides a mock function with given fields: name, address, handlers
+func (_m *CatapultClient) AddTopicHandlers(name string, address *sdk.Address, handlers ...subscribers.CustomHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, name)
+	_ca = append(_ca, address)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *sdk.Address, ...subscribers.CustomHandler) error); ok {
+		r0 = rf(name, address, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddUnconfirmedAddedHandlers provides a mock function with given fields: address, handlers
 func (_m *CatapultClient) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
 	_va := make([]interface{}, len(handlers))
@@ -178,6 +265,49 @@ func (_m *CatapultClient) AddUnconfirmedAddedHandlers(address *sdk.Address, hand
 	return r0
 }
 
+// AddUnconfirmedAddedHandlersForAddresses provides a mock function with given fields: addresses, handlers
+func (_m *CatapultClient) AddUnconfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, addresses)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*sdk.Address, ...subscribers.UnconfirmedAddedHandler) error); ok {
+		r0 = rf(addresses, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddUnconfirmedAddedHandlersWithFilter provides a mock function with given fields: address, filter, handlers
+func (_m *CatapultClient) AddUnconfirmedAddedHandlersWithFilter(address *sdk.Address, filter websocket.TransactionFilter, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, address)
+	_ca = append(_ca, filter)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.TransactionFilter, ...subscribers.UnconfirmedAddedHandler) error); ok {
+		r0 = rf(address, filter, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddUnconfirmedRemovedHandlers provides a mock function with given fields: address, handlers
 func (_m *CatapultClient) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) error {
 	_va := make([]interface{}, len(handlers))
@@ -213,7 +343,167 @@ func (_m *CatapultClient) Close() error {
 	return r0
 }
 
+// Config provides a mock function with given fields:
+func (_m *CatapultClient) Config() *sdk.Config {
+	ret := _m.Called()
+
+	var r0 *sdk.Config
+	if rf, ok := ret.Get(0).(func() *sdk.Config); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sdk.Config)
+		}
+	}
+
+	return r0
+}
+
 // Listen provides a mock function with given fields:
 func (_m *CatapultClient) Listen() {
 	_m.Called()
 }
+
+// Metrics provides a mock function with given fields:
+func (_m *CatapultClient) Metrics() websocket.DispatcherMetrics {
+	ret := _m.Called()
+
+	var r0 websocket.DispatcherMetrics
+	if rf, ok := ret.Get(0).(func() websocket.DispatcherMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(websocket.DispatcherMetrics)
+	}
+
+	return r0
+}
+
+// OnLockFunds provides a mock function with given fields: address, handler
+func (_m *CatapultClient) OnLockFunds(address *sdk.Address, handler websocket.LockFundsHandler) error {
+	ret := _m.Called(address, handler)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.LockFundsHandler) error); ok {
+		r0 = rf(address, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnSecretLock provides a mock function with given fields: address, handler
+func (_m *CatapultClient) OnSecretLock(address *sdk.Address, handler websocket.SecretLockHandler) error {
+	ret := _m.Called(address, handler)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.SecretLockHandler) error); ok {
+		r0 = rf(address, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnSecretProof provides a mock function with given fields: address, handler
+func (_m *CatapultClient) OnSecretProof(address *sdk.Address, handler websocket.SecretProofHandler) error {
+	ret := _m.Called(address, handler)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.SecretProofHandler) error); ok {
+		r0 = rf(address, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OnTransfer provides a mock function with given fields: address, handler
+func (_m *CatapultClient) OnTransfer(address *sdk.Address, handler websocket.TransferHandler) error {
+	ret := _m.Called(address, handler)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*sdk.Address, websocket.TransferHandler) error); ok {
+		r0 = rf(address, handler)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegisterTopic provides a mock function with given fields: topic
+func (_m *CatapultClient) RegisterTopic(topic *websocket.CustomTopic) error {
+	ret := _m.Called(topic)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*websocket.CustomTopic) error); ok {
+		r0 = rf(topic)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveConfirmedAddedAddresses provides a mock function with given fields: addresses
+func (_m *CatapultClient) RemoveConfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	_va := make([]interface{}, len(addresses))
+	for _i := range addresses {
+		_va[_i] = addresses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...*sdk.Address) error); ok {
+		r0 = rf(addresses...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveStatusAddresses provides a mock function with given fields: addresses
+func (_m *CatapultClient) RemoveStatusAddresses(addresses ...*sdk.Address) error {
+	_va := make([]interface{}, len(addresses))
+	for _i := range addresses {
+		_va[_i] = addresses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...*sdk.Address) error); ok {
+		r0 = rf(addresses...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveUnconfirmedAddedAddresses provides a mock function with given fields: addresses
+func (_m *CatapultClient) RemoveUnconfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	_va := make([]interface{}, len(addresses))
+	for _i := range addresses {
+		_va[_i] = addresses[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(...*sdk.Address) error); ok {
+		r0 = rf(addresses...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -39,6 +39,48 @@ func (_m *MessagePublisher) PublishUnsubscribeMessage(uid string, path websocket
 	return r0
 }
 
+// PublishSubscribeMessages provides a mock function with given fields: uid, paths
+func (_m *MessagePublisher) PublishSubscribeMessages(uid string, paths ...websocket.Path) error {
+	_va := make([]interface{}, len(paths))
+	for _i := range paths {
+		_va[_i] = paths[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, uid)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...websocket.Path) error); ok {
+		r0 = rf(uid, paths...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishUnsubscribeMessages provides a mock function with given fields: uid, paths
+func (_m *MessagePublisher) PublishUnsubscribeMessages(uid string, paths ...websocket.Path) error {
+	_va := make([]interface{}, len(paths))
+	for _i := range paths {
+		_va[_i] = paths[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, uid)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...websocket.Path) error); ok {
+		r0 = rf(uid, paths...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetConn provides a mock function with given fields: conn
 func (_m *MessagePublisher) SetConn(conn *gorillawebsocket.Conn) {
 	_m.Called(conn)
@@ -3,15 +3,44 @@
 package mocks
 
 import mock "github.com/stretchr/testify/mock"
+import websocket "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket"
 
 // Router is an autogenerated mock type for the Router type
 type Router struct {
 	mock.Mock
 }
 
+// Close provides a mock function with given fields:
+func (_m *Router) Close() {
+	_m.Called()
+}
+
+// Metrics provides a mock function with given fields:
+func (_m *Router) Metrics() websocket.DispatcherMetrics {
+	ret := _m.Called()
+
+	var r0 websocket.DispatcherMetrics
+	if rf, ok := ret.Get(0).(func() websocket.DispatcherMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(websocket.DispatcherMetrics)
+	}
+
+	return r0
+}
+
 // RouteMessage provides a mock function with given fields: _a0
-func (_m *Router) RouteMessage(_a0 []byte) {
-	_m.Called(_a0)
+func (_m *Router) RouteMessage(_a0 []byte) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]byte) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // SetUid provides a mock function with given fields: _a0
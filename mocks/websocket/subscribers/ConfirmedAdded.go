@@ -32,6 +32,27 @@ func (_m *ConfirmedAdded) AddHandlers(address *sdk.Address, handlers ...subscrib
 	return r0
 }
 
+// AddHandlersForAddresses provides a mock function with given fields: addresses, handlers
+func (_m *ConfirmedAdded) AddHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	_va := make([]interface{}, len(handlers))
+	for _i := range handlers {
+		_va[_i] = handlers[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, addresses)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*sdk.Address, ...subscribers.ConfirmedAddedHandler) error); ok {
+		r0 = rf(addresses, handlers...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAddresses provides a mock function with given fields:
 func (_m *ConfirmedAdded) GetAddresses() []string {
 	ret := _m.Called()
@@ -78,6 +99,20 @@ func (_m *ConfirmedAdded) HasHandlers(address *sdk.Address) bool {
 	return r0
 }
 
+// RemoveAddress provides a mock function with given fields: address
+func (_m *ConfirmedAdded) RemoveAddress(address *sdk.Address) bool {
+	ret := _m.Called(address)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(*sdk.Address) bool); ok {
+		r0 = rf(address)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // RemoveHandlers provides a mock function with given fields: address, handlers
 func (_m *ConfirmedAdded) RemoveHandlers(address *sdk.Address, handlers ...*subscribers.ConfirmedAddedHandler) bool {
 	_va := make([]interface{}, len(handlers))
@@ -0,0 +1,340 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// AccountPropertiesSetup is desired final state of account properties.
+// Every kind of values is either allowed or blocked, so for every kind only one of lists can be non empty.
+// Empty lists of a kind remove all current properties of that kind
+type AccountPropertiesSetup struct {
+	AllowedAddresses   []*Address
+	AllowedMosaicId    []*MosaicId
+	AllowedEntityTypes []EntityType
+	BlockedAddresses   []*Address
+	BlockedMosaicId    []*MosaicId
+	BlockedEntityTypes []EntityType
+}
+
+// AccountPropertiesChange contains transactions which turn current properties of account into AccountPropertiesSetup
+type AccountPropertiesChange struct {
+	Account *PublicAccount
+	// Properties without values when account doesn't have properties yet
+	Current *AccountProperties
+	Setup   *AccountPropertiesSetup
+	// Transactions of Account which should be applied in order inside of one aggregate.
+	// When mode of a kind is switched, current values are removed before new ones are added
+	Transactions []Transaction
+}
+
+// returns change of account properties from current state to passed setup.
+// Setups which the node refuses, e.g. with allowed and blocked values of the same kind, are rejected
+func NewAccountPropertiesChange(deadline *Deadline, account *PublicAccount, current *AccountProperties, setup *AccountPropertiesSetup, networkType NetworkType) (*AccountPropertiesChange, error) {
+	if account == nil {
+		return nil, ErrNilAccount
+	}
+
+	if setup == nil {
+		return nil, ErrNilAccountPropertiesSetup
+	}
+
+	if current == nil {
+		current = &AccountProperties{Address: account.Address}
+	}
+
+	change := &AccountPropertiesChange{
+		Account:      account,
+		Current:      current,
+		Setup:        setup,
+		Transactions: make([]Transaction, 0),
+	}
+
+	addressTxs, err := reconcileAddressProperties(deadline, account, current, setup, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	mosaicTxs, err := reconcileMosaicProperties(deadline, current, setup, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	entityTypeTxs, err := reconcileEntityTypeProperties(deadline, current, setup, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Transactions = append(change.Transactions, addressTxs...)
+	change.Transactions = append(change.Transactions, mosaicTxs...)
+	change.Transactions = append(change.Transactions, entityTypeTxs...)
+
+	if len(change.Transactions) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	return change, nil
+}
+
+// returns change of account properties from current state requested from REST to passed setup
+func (c *Client) NewAccountPropertiesChange(ctx context.Context, deadline *Deadline, account *PublicAccount, setup *AccountPropertiesSetup) (*AccountPropertiesChange, error) {
+	if account == nil {
+		return nil, ErrNilAccount
+	}
+
+	current, err := c.Account.GetAccountProperties(ctx, account.Address)
+	if err != nil && err != ErrResourceNotFound {
+		return nil, err
+	}
+
+	change, err := NewAccountPropertiesChange(deadline, account, current, setup, c.config.NetworkType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range change.Transactions {
+		if err = c.modifyTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return change, nil
+}
+
+// returns complete aggregate of change transactions, which should be signed by account of change
+func (c *Client) NewAccountPropertiesAggregateTransaction(deadline *Deadline, change *AccountPropertiesChange) (*AggregateTransaction, error) {
+	for _, tx := range change.Transactions {
+		tx.GetAbstractTransaction().ToAggregate(change.Account)
+	}
+
+	return c.NewCompleteAggregateTransaction(deadline, change.Transactions)
+}
+
+type propertyValue struct {
+	key string
+	// *Address, *MosaicId or EntityType
+	value interface{}
+}
+
+type propertyModification struct {
+	modificationType PropertyModificationType
+	value            interface{}
+}
+
+type propertyUpdate struct {
+	propertyType  PropertyType
+	modifications []*propertyModification
+}
+
+// returns updates of one kind of properties. Kind which loses all values is updated first,
+// so values of the other mode are never added while the kind still has values of current mode
+func reconcileProperty(allowType, blockType PropertyType, currentAllowed, currentBlocked, allowed, blocked []propertyValue) ([]*propertyUpdate, error) {
+	if len(allowed) > 0 && len(blocked) > 0 {
+		return nil, errors.Wrapf(ErrPropertyModeConflict, "property type %d", allowType)
+	}
+
+	for _, values := range [][]propertyValue{allowed, blocked} {
+		seen := make(map[string]bool, len(values))
+		for _, v := range values {
+			if seen[v.key] {
+				return nil, errors.Wrapf(ErrDuplicatePropertyValue, "value %s", v.key)
+			}
+			seen[v.key] = true
+		}
+	}
+
+	type modeState struct {
+		propertyType     PropertyType
+		current, desired []propertyValue
+	}
+
+	first := modeState{allowType, currentAllowed, allowed}
+	second := modeState{blockType, currentBlocked, blocked}
+	if len(allowed) > 0 {
+		first, second = second, first
+	}
+
+	updates := make([]*propertyUpdate, 0)
+	for _, mode := range []modeState{first, second} {
+		update := &propertyUpdate{propertyType: mode.propertyType}
+
+		for _, v := range propertyValuesDifference(mode.current, mode.desired) {
+			update.modifications = append(update.modifications, &propertyModification{RemoveProperty, v.value})
+		}
+
+		for _, v := range propertyValuesDifference(mode.desired, mode.current) {
+			update.modifications = append(update.modifications, &propertyModification{AddProperty, v.value})
+		}
+
+		if len(update.modifications) > 0 {
+			updates = append(updates, update)
+		}
+	}
+
+	return updates, nil
+}
+
+// returns values of left which aren't in right keeping order of left
+func propertyValuesDifference(left, right []propertyValue) []propertyValue {
+	inRight := make(map[string]bool, len(right))
+	for _, v := range right {
+		inRight[v.key] = true
+	}
+
+	difference := make([]propertyValue, 0)
+	for _, v := range left {
+		if !inRight[v.key] {
+			difference = append(difference, v)
+		}
+	}
+
+	return difference
+}
+
+func reconcileAddressProperties(deadline *Deadline, account *PublicAccount, current *AccountProperties, setup *AccountPropertiesSetup, networkType NetworkType) ([]Transaction, error) {
+	for _, addresses := range [][]*Address{setup.AllowedAddresses, setup.BlockedAddresses} {
+		for _, a := range addresses {
+			if a == nil {
+				return nil, ErrNilAddress
+			}
+
+			// the node doesn't allow account to restrict itself
+			if a.Address == account.Address.Address {
+				return nil, errors.Wrapf(ErrPropertyOwnAddress, "address %s", a.Address)
+			}
+		}
+	}
+
+	updates, err := reconcileProperty(AllowAddress, BlockAddress,
+		addressPropertyValues(current.AllowedAddresses), addressPropertyValues(current.BlockedAddresses),
+		addressPropertyValues(setup.AllowedAddresses), addressPropertyValues(setup.BlockedAddresses))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]Transaction, 0, len(updates))
+	for _, update := range updates {
+		modifications := make([]*AccountPropertiesAddressModification, len(update.modifications))
+		for i, m := range update.modifications {
+			modifications[i] = &AccountPropertiesAddressModification{m.modificationType, m.value.(*Address)}
+		}
+
+		tx, err := NewAccountPropertiesAddressTransaction(deadline, update.propertyType, modifications, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+func reconcileMosaicProperties(deadline *Deadline, current *AccountProperties, setup *AccountPropertiesSetup, networkType NetworkType) ([]Transaction, error) {
+	for _, mosaicIds := range [][]*MosaicId{setup.AllowedMosaicId, setup.BlockedMosaicId} {
+		for _, m := range mosaicIds {
+			if m == nil {
+				return nil, ErrNilMosaicId
+			}
+		}
+	}
+
+	updates, err := reconcileProperty(AllowMosaic, BlockMosaic,
+		mosaicPropertyValues(current.AllowedMosaicId), mosaicPropertyValues(current.BlockedMosaicId),
+		mosaicPropertyValues(setup.AllowedMosaicId), mosaicPropertyValues(setup.BlockedMosaicId))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]Transaction, 0, len(updates))
+	for _, update := range updates {
+		modifications := make([]*AccountPropertiesMosaicModification, len(update.modifications))
+		for i, m := range update.modifications {
+			modifications[i] = &AccountPropertiesMosaicModification{m.modificationType, m.value.(*MosaicId)}
+		}
+
+		tx, err := NewAccountPropertiesMosaicTransaction(deadline, update.propertyType, modifications, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+func reconcileEntityTypeProperties(deadline *Deadline, current *AccountProperties, setup *AccountPropertiesSetup, networkType NetworkType) ([]Transaction, error) {
+	// the node refuses properties which would block further changes of entity type properties
+	if containsEntityType(setup.BlockedEntityTypes, AccountPropertyEntityType) ||
+		(len(setup.AllowedEntityTypes) > 0 && !containsEntityType(setup.AllowedEntityTypes, AccountPropertyEntityType)) {
+		return nil, ErrPropertySelfBlocking
+	}
+
+	updates, err := reconcileProperty(AllowTransaction, BlockTransaction,
+		entityTypePropertyValues(current.AllowedEntityTypes), entityTypePropertyValues(current.BlockedEntityTypes),
+		entityTypePropertyValues(setup.AllowedEntityTypes), entityTypePropertyValues(setup.BlockedEntityTypes))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]Transaction, 0, len(updates))
+	for _, update := range updates {
+		modifications := make([]*AccountPropertiesEntityTypeModification, len(update.modifications))
+		for i, m := range update.modifications {
+			modifications[i] = &AccountPropertiesEntityTypeModification{m.modificationType, m.value.(EntityType)}
+		}
+
+		tx, err := NewAccountPropertiesEntityTypeTransaction(deadline, update.propertyType, modifications, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		txs = append(txs, tx)
+	}
+
+	return txs, nil
+}
+
+func addressPropertyValues(addresses []*Address) []propertyValue {
+	values := make([]propertyValue, len(addresses))
+	for i, a := range addresses {
+		values[i] = propertyValue{a.Address, a}
+	}
+
+	return values
+}
+
+func mosaicPropertyValues(mosaicIds []*MosaicId) []propertyValue {
+	values := make([]propertyValue, len(mosaicIds))
+	for i, m := range mosaicIds {
+		values[i] = propertyValue{m.String(), m}
+	}
+
+	return values
+}
+
+func entityTypePropertyValues(types []EntityType) []propertyValue {
+	values := make([]propertyValue, len(types))
+	for i, t := range types {
+		values[i] = propertyValue{fmt.Sprintf("%X", uint16(t)), t}
+	}
+
+	return values
+}
+
+func containsEntityType(types []EntityType, entityType EntityType) bool {
+	for _, t := range types {
+		if t == entityType {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,105 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAccountPropertiesChange(t *testing.T) {
+	account := testMultisigOutsider(t).PublicAccount
+	first, second := newAddressFromRaw(nemTestAddress1), newAddressFromRaw(nemTestAddress2)
+	mosaicA, mosaicB := newMosaicIdPanic(1), newMosaicIdPanic(2)
+
+	current := &AccountProperties{
+		Address:            account.Address,
+		AllowedAddresses:   []*Address{first},
+		BlockedMosaicId:    []*MosaicId{mosaicA},
+		AllowedEntityTypes: []EntityType{AccountPropertyEntityType, Transfer},
+	}
+
+	change, err := NewAccountPropertiesChange(fakeDeadline, account, current, &AccountPropertiesSetup{
+		// allowed addresses become blocked, so allowed one is removed before blocked one is added
+		BlockedAddresses:   []*Address{second},
+		BlockedMosaicId:    []*MosaicId{mosaicA, mosaicB},
+		AllowedEntityTypes: []EntityType{AccountPropertyEntityType, Transfer},
+	}, MijinTest)
+	assert.Nil(t, err)
+	assert.Len(t, change.Transactions, 3)
+
+	unallow := change.Transactions[0].(*AccountPropertiesAddressTransaction)
+	assert.Equal(t, AllowAddress, unallow.PropertyType)
+	assert.Equal(t, []*AccountPropertiesAddressModification{{RemoveProperty, first}}, unallow.Modifications)
+
+	block := change.Transactions[1].(*AccountPropertiesAddressTransaction)
+	assert.Equal(t, BlockAddress, block.PropertyType)
+	assert.Equal(t, []*AccountPropertiesAddressModification{{AddProperty, second}}, block.Modifications)
+
+	mosaics := change.Transactions[2].(*AccountPropertiesMosaicTransaction)
+	assert.Equal(t, BlockMosaic, mosaics.PropertyType)
+	assert.Equal(t, []*AccountPropertiesMosaicModification{{AddProperty, mosaicB}}, mosaics.Modifications)
+
+	// clearing of entity types is one removal transaction
+	change, err = NewAccountPropertiesChange(fakeDeadline, account, current, &AccountPropertiesSetup{
+		AllowedAddresses: []*Address{first},
+		BlockedMosaicId:  []*MosaicId{mosaicA},
+	}, MijinTest)
+	assert.Nil(t, err)
+	assert.Len(t, change.Transactions, 1)
+	entityTypes := change.Transactions[0].(*AccountPropertiesEntityTypeTransaction)
+	assert.Equal(t, AllowTransaction, entityTypes.PropertyType)
+	assert.Len(t, entityTypes.Modifications, 2)
+	assert.Equal(t, RemoveProperty, entityTypes.Modifications[0].ModificationType)
+}
+
+func TestNewAccountPropertiesChange_Rejected(t *testing.T) {
+	account := testMultisigOutsider(t).PublicAccount
+	address := newAddressFromRaw(nemTestAddress1)
+
+	newChange := func(setup *AccountPropertiesSetup) error {
+		_, err := NewAccountPropertiesChange(fakeDeadline, account, nil, setup, MijinTest)
+		return errors.Cause(err)
+	}
+
+	assert.Equal(t, ErrNilAccountPropertiesSetup, newChange(nil))
+	assert.Equal(t, ErrNoChanges, newChange(&AccountPropertiesSetup{}))
+	assert.Equal(t, ErrPropertyModeConflict, newChange(&AccountPropertiesSetup{
+		AllowedMosaicId: []*MosaicId{newMosaicIdPanic(1)},
+		BlockedMosaicId: []*MosaicId{newMosaicIdPanic(2)},
+	}))
+	assert.Equal(t, ErrDuplicatePropertyValue, newChange(&AccountPropertiesSetup{BlockedAddresses: []*Address{address, address}}))
+	assert.Equal(t, ErrPropertyOwnAddress, newChange(&AccountPropertiesSetup{BlockedAddresses: []*Address{account.Address}}))
+	assert.Equal(t, ErrPropertySelfBlocking, newChange(&AccountPropertiesSetup{BlockedEntityTypes: []EntityType{AccountPropertyEntityType}}))
+	assert.Equal(t, ErrPropertySelfBlocking, newChange(&AccountPropertiesSetup{AllowedEntityTypes: []EntityType{Transfer}}))
+}
+
+func TestClient_NewAccountPropertiesChange(t *testing.T) {
+	account := testMultisigOutsider(t).PublicAccount
+
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     fmt.Sprintf(accountPropertiesRoute, account.Address.Address),
+		RespBody: accountPropertiesJson,
+	})
+	defer mockServ.Close()
+
+	conf, err := NewConfigWithReputation([]string{mockServ.GetServerURL()}, MijinTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, &Hash{1}, DefaultFeeCalculationStrategy)
+	assert.Nil(t, err)
+	client := NewClient(nil, conf)
+
+	// every current property is removed
+	change, err := client.NewAccountPropertiesChange(ctx, fakeDeadline, account, &AccountPropertiesSetup{})
+	assert.Nil(t, err)
+	assert.Len(t, change.Transactions, 6)
+
+	aggregate, err := client.NewAccountPropertiesAggregateTransaction(fakeDeadline, change)
+	assert.Nil(t, err)
+	assert.Equal(t, AggregateCompleted, aggregate.Type)
+	assert.Equal(t, account, aggregate.InnerTransactions[0].GetAbstractTransaction().Signer)
+}
@@ -0,0 +1,436 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+// Package analytics computes statistics of block production and transactions over a range of blocks
+package analytics
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const (
+	defaultPageSize    sdk.Amount = 100
+	defaultBucketWidth            = 5 * time.Second
+)
+
+var (
+	ErrInvalidRange    = errors.New("range of blocks is invalid")
+	ErrIncompleteRange = errors.New("node returned incomplete range of blocks")
+)
+
+// BlockSource is the part of sdk.BlockchainService used by Analyzer
+type BlockSource interface {
+	GetBlocksByHeightWithLimit(ctx context.Context, height sdk.Height, limit sdk.Amount) ([]*sdk.BlockInfo, error)
+	GetBlockTransactions(ctx context.Context, height sdk.Height) ([]sdk.Transaction, error)
+}
+
+type Config struct {
+	// Number of blocks requested by one REST call. Zero means 100
+	PageSize sdk.Amount
+	// Width of block time histogram buckets. Zero means 5 seconds
+	BucketWidth time.Duration
+	// Skips GetBlockTransactions calls, so entity type mix is not computed
+	SkipTransactions bool
+	// Percent of collected fee paid to beneficiary of block, see harvestBeneficiaryPercentage of network config
+	BeneficiaryPercentage uint64
+	// Location of days in RewardsReport. Nil means UTC
+	DayLocation *time.Location
+}
+
+// Analyzer computes Report over a range of blocks
+type Analyzer struct {
+	blockchain BlockSource
+	config     Config
+}
+
+// returns Analyzer which requests blocks from passed source, e.g. sdk.Client.Blockchain
+func NewAnalyzer(blockchain BlockSource, cfg Config) *Analyzer {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = defaultPageSize
+	}
+
+	if cfg.BucketWidth <= 0 {
+		cfg.BucketWidth = defaultBucketWidth
+	}
+
+	return &Analyzer{
+		blockchain: blockchain,
+		config:     cfg,
+	}
+}
+
+// BlockTimeBucket counts block times in range [From, To)
+type BlockTimeBucket struct {
+	From  time.Duration
+	To    time.Duration
+	Count int
+}
+
+// BlockTimeDistribution describes actual time between consecutive blocks
+type BlockTimeDistribution struct {
+	Samples int
+	Min     time.Duration
+	Max     time.Duration
+	Mean    time.Duration
+	StdDev  time.Duration
+	Median  time.Duration
+	P90     time.Duration
+	P99     time.Duration
+	Buckets []*BlockTimeBucket
+}
+
+// FeeMultiplierPercentiles describes fee multipliers of harvested blocks
+type FeeMultiplierPercentiles struct {
+	Min    uint32
+	P25    uint32
+	Median uint32
+	P75    uint32
+	P90    uint32
+	Max    uint32
+	Mean   float64
+}
+
+// HarvesterShare is a number of blocks and fees related to one account
+type HarvesterShare struct {
+	Account *sdk.PublicAccount
+	Blocks  int
+	// Part of analyzed blocks from 0 to 1
+	Share    float64
+	TotalFee sdk.Amount
+}
+
+// EntityTypeShare is a number of transactions of one type
+type EntityTypeShare struct {
+	Type  sdk.EntityType
+	Count int
+	// Part of transactions from 0 to 1
+	Share float64
+}
+
+// Report contains statistics of blocks in range [From, To]
+type Report struct {
+	From   sdk.Height
+	To     sdk.Height
+	Blocks int
+	// Time between timestamps of the first and the last block
+	Duration time.Duration
+
+	BlockTimes BlockTimeDistribution
+
+	Transactions          uint64
+	EmptyBlocks           int
+	MaxTransactions       uint64
+	TransactionsPerBlock  float64
+	TransactionsPerSecond float64
+
+	FeeMultipliers FeeMultiplierPercentiles
+
+	// Sorted by number of blocks in descending order
+	Signers       []*HarvesterShare
+	Beneficiaries []*HarvesterShare
+
+	// Types of top-level transactions sorted by count in descending order
+	EntityTypes []*EntityTypeShare
+	// Types of transactions inside aggregates sorted by count in descending order
+	InnerEntityTypes []*EntityTypeShare
+}
+
+// Analyze returns statistics of blocks in range [from, to]
+func (a *Analyzer) Analyze(ctx context.Context, from, to sdk.Height) (*Report, error) {
+	if from == 0 {
+		return nil, sdk.ErrNilOrZeroHeight
+	}
+
+	if to < from {
+		return nil, ErrInvalidRange
+	}
+
+	blocks, err := a.blocks(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{
+		From:   from,
+		To:     to,
+		Blocks: len(blocks),
+	}
+
+	r.addBlockTimes(blocks, a.config.BucketWidth)
+	r.addTransactionCounts(blocks)
+	r.addFeeMultipliers(blocks)
+	r.addHarvesters(blocks)
+
+	if a.config.SkipTransactions {
+		return r, nil
+	}
+
+	types, innerTypes := make(map[sdk.EntityType]int), make(map[sdk.EntityType]int)
+	for _, b := range blocks {
+		if b.NumTransactions == 0 {
+			continue
+		}
+
+		txs, err := a.blockchain.GetBlockTransactions(ctx, b.Height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting transactions of block %d", b.Height)
+		}
+
+		for _, tx := range txs {
+			types[tx.GetAbstractTransaction().Type]++
+
+			if aggregate, ok := tx.(*sdk.AggregateTransaction); ok {
+				for _, inner := range aggregate.InnerTransactions {
+					innerTypes[inner.GetAbstractTransaction().Type]++
+				}
+			}
+		}
+	}
+
+	r.EntityTypes = entityTypeShares(types)
+	r.InnerEntityTypes = entityTypeShares(innerTypes)
+
+	return r, nil
+}
+
+// returns every block of range [from, to] in ascending order
+func (a *Analyzer) blocks(ctx context.Context, from, to sdk.Height) ([]*sdk.BlockInfo, error) {
+	blocks := make([]*sdk.BlockInfo, 0, to-from+1)
+
+	for next := from; next <= to; {
+		page, err := a.blockchain.GetBlocksByHeightWithLimit(ctx, next, a.config.PageSize)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting blocks from height %d", next)
+		}
+
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].Height < page[j].Height
+		})
+
+		start := next
+		for _, b := range page {
+			if b.Height < next || b.Height > to {
+				continue
+			}
+
+			if b.Height != next {
+				return nil, errors.Wrapf(ErrIncompleteRange, "expected block %d, got %d", next, b.Height)
+			}
+
+			blocks = append(blocks, b)
+			next++
+		}
+
+		if next == start {
+			return nil, errors.Wrapf(ErrIncompleteRange, "block %d is not returned", next)
+		}
+	}
+
+	return blocks, nil
+}
+
+func (r *Report) addBlockTimes(blocks []*sdk.BlockInfo, bucketWidth time.Duration) {
+	first, last := blocks[0], blocks[len(blocks)-1]
+	if first.Timestamp != nil && last.Timestamp != nil {
+		r.Duration = last.Timestamp.Sub(first.Timestamp.Time)
+	}
+
+	times := make([]int64, 0, len(blocks))
+	for i := 1; i < len(blocks); i++ {
+		previous, current := blocks[i-1], blocks[i]
+		if previous.Timestamp == nil || current.Timestamp == nil {
+			continue
+		}
+
+		times = append(times, int64(current.Timestamp.Sub(previous.Timestamp.Time)))
+	}
+
+	if len(times) == 0 {
+		return
+	}
+
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+
+	mean, stdDev := meanAndStdDev(times)
+
+	d := &r.BlockTimes
+	d.Samples = len(times)
+	d.Min = time.Duration(times[0])
+	d.Max = time.Duration(times[len(times)-1])
+	d.Mean = time.Duration(mean)
+	d.StdDev = time.Duration(stdDev)
+	d.Median = time.Duration(percentile(times, 50))
+	d.P90 = time.Duration(percentile(times, 90))
+	d.P99 = time.Duration(percentile(times, 99))
+
+	// buckets cover range from the bucket of min to the bucket of max without gaps
+	width := int64(bucketWidth)
+	low := floorDiv(times[0], width)
+	d.Buckets = make([]*BlockTimeBucket, floorDiv(times[len(times)-1], width)-low+1)
+	for i := range d.Buckets {
+		d.Buckets[i] = &BlockTimeBucket{
+			From: time.Duration((low + int64(i)) * width),
+			To:   time.Duration((low + int64(i) + 1) * width),
+		}
+	}
+
+	for _, t := range times {
+		d.Buckets[floorDiv(t, width)-low].Count++
+	}
+}
+
+func (r *Report) addTransactionCounts(blocks []*sdk.BlockInfo) {
+	for _, b := range blocks {
+		r.Transactions += b.NumTransactions
+
+		if b.NumTransactions == 0 {
+			r.EmptyBlocks++
+		}
+
+		if b.NumTransactions > r.MaxTransactions {
+			r.MaxTransactions = b.NumTransactions
+		}
+	}
+
+	r.TransactionsPerBlock = float64(r.Transactions) / float64(len(blocks))
+
+	if r.Duration > 0 {
+		r.TransactionsPerSecond = float64(r.Transactions) / r.Duration.Seconds()
+	}
+}
+
+func (r *Report) addFeeMultipliers(blocks []*sdk.BlockInfo) {
+	multipliers := make([]int64, 0, len(blocks))
+	for _, b := range blocks {
+		multipliers = append(multipliers, int64(b.FeeMultiplier))
+	}
+
+	sort.Slice(multipliers, func(i, j int) bool {
+		return multipliers[i] < multipliers[j]
+	})
+
+	mean, _ := meanAndStdDev(multipliers)
+
+	r.FeeMultipliers = FeeMultiplierPercentiles{
+		Min:    uint32(multipliers[0]),
+		P25:    uint32(percentile(multipliers, 25)),
+		Median: uint32(percentile(multipliers, 50)),
+		P75:    uint32(percentile(multipliers, 75)),
+		P90:    uint32(percentile(multipliers, 90)),
+		Max:    uint32(multipliers[len(multipliers)-1]),
+		Mean:   mean,
+	}
+}
+
+func (r *Report) addHarvesters(blocks []*sdk.BlockInfo) {
+	signers, beneficiaries := make(map[string]*HarvesterShare), make(map[string]*HarvesterShare)
+	for _, b := range blocks {
+		addHarvesterBlock(signers, b.Signer, b.TotalFee)
+		addHarvesterBlock(beneficiaries, b.Beneficiary, b.TotalFee)
+	}
+
+	r.Signers = harvesterShares(signers, len(blocks))
+	r.Beneficiaries = harvesterShares(beneficiaries, len(blocks))
+}
+
+func addHarvesterBlock(shares map[string]*HarvesterShare, account *sdk.PublicAccount, fee sdk.Amount) {
+	if account == nil {
+		return
+	}
+
+	share, ok := shares[account.PublicKey]
+	if !ok {
+		share = &HarvesterShare{Account: account}
+		shares[account.PublicKey] = share
+	}
+
+	share.Blocks++
+	share.TotalFee += fee
+}
+
+func harvesterShares(shares map[string]*HarvesterShare, blocks int) []*HarvesterShare {
+	result := make([]*HarvesterShare, 0, len(shares))
+	for _, share := range shares {
+		share.Share = float64(share.Blocks) / float64(blocks)
+		result = append(result, share)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Blocks != result[j].Blocks {
+			return result[i].Blocks > result[j].Blocks
+		}
+		return result[i].Account.PublicKey < result[j].Account.PublicKey
+	})
+
+	return result
+}
+
+func entityTypeShares(counts map[sdk.EntityType]int) []*EntityTypeShare {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	result := make([]*EntityTypeShare, 0, len(counts))
+	for entityType, count := range counts {
+		result = append(result, &EntityTypeShare{
+			Type:  entityType,
+			Count: count,
+			Share: float64(count) / float64(total),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Type < result[j].Type
+	})
+
+	return result
+}
+
+// returns value at passed percentile of sorted values by nearest-rank method
+func percentile(sorted []int64, p float64) int64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+
+	return sorted[rank-1]
+}
+
+func meanAndStdDev(values []int64) (float64, float64) {
+	sum := 0.0
+	for _, v := range values {
+		sum += float64(v)
+	}
+	mean := sum / float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (float64(v) - mean) * (float64(v) - mean)
+	}
+
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// rounds down also negative values, e.g. block times of blocks with unordered timestamps
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && a < 0 {
+		q--
+	}
+
+	return q
+}
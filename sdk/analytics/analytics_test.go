@@ -0,0 +1,161 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+var (
+	testStart     = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	testHarvester = &sdk.PublicAccount{PublicKey: "AAAA"}
+	testRemote    = &sdk.PublicAccount{PublicKey: "BBBB"}
+)
+
+type fakeBlockSource struct {
+	blocks          map[sdk.Height]*sdk.BlockInfo
+	txRequests      []sdk.Height
+	skipBlockHeight sdk.Height
+}
+
+// blocks 1..5 with block times 10s, 10s, 20s and 15s
+func newFakeBlockSource() *fakeBlockSource {
+	offsets := []time.Duration{0, 10 * time.Second, 20 * time.Second, 40 * time.Second, 55 * time.Second}
+	multipliers := []uint32{10, 20, 30, 40, 50}
+	transactions := []uint64{0, 2, 1, 0, 3}
+
+	s := &fakeBlockSource{blocks: make(map[sdk.Height]*sdk.BlockInfo)}
+	for i := range offsets {
+		h := sdk.Height(i + 1)
+		signer := testHarvester
+		if h > 3 {
+			signer = testRemote
+		}
+
+		s.blocks[h] = &sdk.BlockInfo{
+			Height:          h,
+			Timestamp:       &sdk.Timestamp{Time: testStart.Add(offsets[i])},
+			FeeMultiplier:   multipliers[i],
+			NumTransactions: transactions[i],
+			TotalFee:        sdk.Amount(transactions[i] * 100),
+			Signer:          signer,
+			Beneficiary:     testHarvester,
+		}
+	}
+
+	return s
+}
+
+// returns blocks in descending order like REST does
+func (s *fakeBlockSource) GetBlocksByHeightWithLimit(_ context.Context, height sdk.Height, limit sdk.Amount) ([]*sdk.BlockInfo, error) {
+	blocks := make([]*sdk.BlockInfo, 0)
+	for h := height + sdk.Height(limit) - 1; h >= height; h-- {
+		if b, ok := s.blocks[h]; ok && h != s.skipBlockHeight {
+			blocks = append(blocks, b)
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *fakeBlockSource) GetBlockTransactions(_ context.Context, height sdk.Height) ([]sdk.Transaction, error) {
+	s.txRequests = append(s.txRequests, height)
+
+	txs := make([]sdk.Transaction, 0)
+	for i := uint64(0); i < s.blocks[height].NumTransactions; i++ {
+		txs = append(txs, &sdk.TransferTransaction{AbstractTransaction: sdk.AbstractTransaction{Type: sdk.Transfer}})
+	}
+
+	if height == 5 {
+		txs[0] = &sdk.AggregateTransaction{
+			AbstractTransaction: sdk.AbstractTransaction{Type: sdk.AggregateCompleted},
+			InnerTransactions: []sdk.Transaction{
+				&sdk.TransferTransaction{AbstractTransaction: sdk.AbstractTransaction{Type: sdk.Transfer}},
+				&sdk.MosaicDefinitionTransaction{AbstractTransaction: sdk.AbstractTransaction{Type: sdk.MosaicDefinition}},
+			},
+		}
+	}
+
+	return txs, nil
+}
+
+func TestAnalyzer_Analyze(t *testing.T) {
+	source := newFakeBlockSource()
+
+	report, err := NewAnalyzer(source, Config{PageSize: 2, BucketWidth: 10 * time.Second}).Analyze(context.Background(), 1, 5)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 5, report.Blocks)
+	assert.Equal(t, 55*time.Second, report.Duration)
+
+	assert.Equal(t, 4, report.BlockTimes.Samples)
+	assert.Equal(t, 10*time.Second, report.BlockTimes.Min)
+	assert.Equal(t, 20*time.Second, report.BlockTimes.Max)
+	assert.Equal(t, 13750*time.Millisecond, report.BlockTimes.Mean)
+	assert.Equal(t, 10*time.Second, report.BlockTimes.Median)
+	assert.Equal(t, 20*time.Second, report.BlockTimes.P90)
+	assert.Equal(t, []*BlockTimeBucket{
+		{From: 10 * time.Second, To: 20 * time.Second, Count: 3},
+		{From: 20 * time.Second, To: 30 * time.Second, Count: 1},
+	}, report.BlockTimes.Buckets)
+
+	assert.Equal(t, uint64(6), report.Transactions)
+	assert.Equal(t, 2, report.EmptyBlocks)
+	assert.Equal(t, uint64(3), report.MaxTransactions)
+	assert.Equal(t, 1.2, report.TransactionsPerBlock)
+	assert.InDelta(t, 6.0/55, report.TransactionsPerSecond, 1e-9)
+
+	assert.Equal(t, FeeMultiplierPercentiles{Min: 10, P25: 20, Median: 30, P75: 40, P90: 50, Max: 50, Mean: 30}, report.FeeMultipliers)
+
+	assert.Len(t, report.Signers, 2)
+	assert.Equal(t, testHarvester, report.Signers[0].Account)
+	assert.Equal(t, 3, report.Signers[0].Blocks)
+	assert.Equal(t, 0.6, report.Signers[0].Share)
+	assert.Equal(t, sdk.Amount(300), report.Signers[0].TotalFee)
+	assert.Equal(t, testRemote, report.Signers[1].Account)
+	assert.Len(t, report.Beneficiaries, 1)
+	assert.Equal(t, 5, report.Beneficiaries[0].Blocks)
+
+	// only blocks with transactions are requested
+	assert.Equal(t, []sdk.Height{2, 3, 5}, source.txRequests)
+	assert.Equal(t, []*EntityTypeShare{
+		{Type: sdk.Transfer, Count: 5, Share: 5.0 / 6},
+		{Type: sdk.AggregateCompleted, Count: 1, Share: 1.0 / 6},
+	}, report.EntityTypes)
+	assert.Len(t, report.InnerEntityTypes, 2)
+}
+
+func TestAnalyzer_Analyze_Range(t *testing.T) {
+	source := newFakeBlockSource()
+	analyzer := NewAnalyzer(source, Config{SkipTransactions: true})
+
+	report, err := analyzer.Analyze(context.Background(), 4, 4)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.Blocks)
+	assert.Equal(t, 0, report.BlockTimes.Samples)
+	assert.Nil(t, report.EntityTypes)
+	assert.Empty(t, source.txRequests)
+
+	_, err = analyzer.Analyze(context.Background(), 0, 4)
+	assert.Equal(t, sdk.ErrNilOrZeroHeight, err)
+
+	_, err = analyzer.Analyze(context.Background(), 4, 3)
+	assert.Equal(t, ErrInvalidRange, err)
+
+	source.skipBlockHeight = 3
+	_, err = analyzer.Analyze(context.Background(), 1, 5)
+	assert.Equal(t, ErrIncompleteRange, errors.Cause(err))
+
+	source.skipBlockHeight = 0
+	_, err = analyzer.Analyze(context.Background(), 1, 6)
+	assert.Equal(t, ErrIncompleteRange, errors.Cause(err))
+}
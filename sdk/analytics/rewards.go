@@ -0,0 +1,242 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package analytics
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math/bits"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const rewardsDayLayout = "2006-01-02"
+
+var ErrInvalidBeneficiaryPercentage = errors.New("beneficiary percentage should not be greater than 100")
+
+// AccountInfoSource is the part of sdk.AccountService used to find linked remote account
+type AccountInfoSource interface {
+	GetAccountInfo(ctx context.Context, address *sdk.Address) (*sdk.AccountInfo, error)
+}
+
+// DailyRewards are harvesting rewards of account during one day
+type DailyRewards struct {
+	// Start of the day in Config.DayLocation
+	Day               time.Time
+	SignedBlocks      int
+	BeneficiaryBlocks int
+	TotalFee          sdk.Amount
+	CollectedFee      sdk.Amount
+	Reward            sdk.Amount
+}
+
+// RewardsReport contains harvesting rewards of account in blocks of range [From, To]
+type RewardsReport struct {
+	Account *sdk.PublicAccount
+	Remotes []*sdk.PublicAccount
+	From    sdk.Height
+	To      sdk.Height
+	Blocks  int
+	// Blocks signed by account or by one of its remote accounts
+	SignedBlocks int
+	// Blocks where account is beneficiary
+	BeneficiaryBlocks int
+	// Sum of total fees of blocks where account is signer or beneficiary
+	TotalFee sdk.Amount
+	// Part of TotalFee kept by harvesters according to fee interest of blocks
+	CollectedFee sdk.Amount
+	// Parts of CollectedFee received by account as signer and as beneficiary
+	SignerReward      sdk.Amount
+	BeneficiaryReward sdk.Amount
+	Reward            sdk.Amount
+	// Ordered by day. Blocks without timestamp aren't included
+	Days []*DailyRewards
+}
+
+// HarvestingRewards returns rewards of account as signer, directly or via passed remote accounts, and as beneficiary
+// of blocks in range [from, to]. Remote accounts should include every account linked during the range.
+// Collected fee of block is TotalFee * FeeInterest / FeeInterestDenominator, beneficiary receives Config.BeneficiaryPercentage of it
+func (a *Analyzer) HarvestingRewards(ctx context.Context, account *sdk.PublicAccount, from, to sdk.Height, remotes ...*sdk.PublicAccount) (*RewardsReport, error) {
+	if account == nil {
+		return nil, sdk.ErrNilAccount
+	}
+
+	if from == 0 {
+		return nil, sdk.ErrNilOrZeroHeight
+	}
+
+	if to < from {
+		return nil, ErrInvalidRange
+	}
+
+	if a.config.BeneficiaryPercentage > 100 {
+		return nil, ErrInvalidBeneficiaryPercentage
+	}
+
+	blocks, err := a.blocks(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := map[string]bool{account.PublicKey: true}
+	for _, r := range remotes {
+		signers[r.PublicKey] = true
+	}
+
+	location := a.config.DayLocation
+	if location == nil {
+		location = time.UTC
+	}
+
+	r := &RewardsReport{
+		Account: account,
+		Remotes: remotes,
+		From:    from,
+		To:      to,
+		Blocks:  len(blocks),
+		Days:    make([]*DailyRewards, 0),
+	}
+
+	days := make(map[time.Time]*DailyRewards)
+	for _, b := range blocks {
+		signed := b.Signer != nil && signers[b.Signer.PublicKey]
+		beneficiary := b.Beneficiary != nil && b.Beneficiary.PublicKey == account.PublicKey
+		if !signed && !beneficiary {
+			continue
+		}
+
+		collected := collectedFee(b)
+		beneficiaryShare := sdk.Amount(0)
+		if b.Beneficiary != nil && (b.Signer == nil || b.Beneficiary.PublicKey != b.Signer.PublicKey) {
+			beneficiaryShare = percentOf(collected, a.config.BeneficiaryPercentage)
+		}
+
+		var signerReward, beneficiaryReward sdk.Amount
+		if signed {
+			signerReward = collected - beneficiaryShare
+			r.SignedBlocks++
+		}
+
+		if beneficiary {
+			beneficiaryReward = beneficiaryShare
+			r.BeneficiaryBlocks++
+		}
+
+		r.TotalFee += b.TotalFee
+		r.CollectedFee += collected
+		r.SignerReward += signerReward
+		r.BeneficiaryReward += beneficiaryReward
+
+		if b.Timestamp == nil {
+			continue
+		}
+
+		t := b.Timestamp.In(location)
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, location)
+		d, ok := days[day]
+		if !ok {
+			d = &DailyRewards{Day: day}
+			days[day] = d
+			r.Days = append(r.Days, d)
+		}
+
+		if signed {
+			d.SignedBlocks++
+		}
+
+		if beneficiary {
+			d.BeneficiaryBlocks++
+		}
+
+		d.TotalFee += b.TotalFee
+		d.CollectedFee += collected
+		d.Reward += signerReward + beneficiaryReward
+	}
+
+	r.Reward = r.SignerReward + r.BeneficiaryReward
+
+	return r, nil
+}
+
+// WriteCSV writes daily rewards with header
+func (r *RewardsReport) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write([]string{"day", "signed_blocks", "beneficiary_blocks", "total_fee", "collected_fee", "reward"})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range r.Days {
+		err = writer.Write([]string{
+			d.Day.Format(rewardsDayLayout),
+			strconv.Itoa(d.SignedBlocks),
+			strconv.Itoa(d.BeneficiaryBlocks),
+			strconv.FormatInt(int64(d.TotalFee), 10),
+			strconv.FormatInt(int64(d.CollectedFee), 10),
+			strconv.FormatInt(int64(d.Reward), 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// LinkedRemote returns remote account currently linked to account or nil when account isn't linked.
+// Links made before the current one aren't known, so remotes which harvested earlier in analyzed range
+// should be passed to HarvestingRewards in addition to this one
+func LinkedRemote(ctx context.Context, accounts AccountInfoSource, account *sdk.PublicAccount) (*sdk.PublicAccount, error) {
+	if account == nil {
+		return nil, sdk.ErrNilAccount
+	}
+
+	info, err := accounts.GetAccountInfo(ctx, account.Address)
+	if err == sdk.ErrResourceNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if info.AccountType != sdk.MainAccount {
+		return nil, nil
+	}
+
+	return info.LinkedAccount, nil
+}
+
+// returns part of total fee kept by harvesters. Block without fee interest keeps the whole fee
+func collectedFee(b *sdk.BlockInfo) sdk.Amount {
+	if b.TotalFee <= 0 || b.FeeInterestDenominator == 0 || b.FeeInterest >= b.FeeInterestDenominator {
+		return b.TotalFee
+	}
+
+	// fee interest is less than denominator, so the quotient fits into 64 bits
+	hi, lo := bits.Mul64(uint64(b.TotalFee), uint64(b.FeeInterest))
+	quotient, _ := bits.Div64(hi, lo, uint64(b.FeeInterestDenominator))
+
+	return sdk.Amount(quotient)
+}
+
+// returns percentage of amount rounded down. Percentage is not greater than 100, so the quotient fits into 64 bits
+func percentOf(amount sdk.Amount, percentage uint64) sdk.Amount {
+	if amount <= 0 {
+		return 0
+	}
+
+	hi, lo := bits.Mul64(uint64(amount), percentage)
+	quotient, _ := bits.Div64(hi, lo, 100)
+
+	return sdk.Amount(quotient)
+}
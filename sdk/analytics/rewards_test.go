@@ -0,0 +1,101 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+type fakeAccountInfoSource struct {
+	info *sdk.AccountInfo
+}
+
+func (s *fakeAccountInfoSource) GetAccountInfo(context.Context, *sdk.Address) (*sdk.AccountInfo, error) {
+	if s.info == nil {
+		return nil, sdk.ErrResourceNotFound
+	}
+
+	return s.info, nil
+}
+
+func TestAnalyzer_HarvestingRewards(t *testing.T) {
+	other := &sdk.PublicAccount{PublicKey: "CCCC"}
+
+	source := newFakeBlockSource()
+	// block 3 keeps half of fee, block 4 is harvested by other account for harvester, block 5 is on the next day
+	source.blocks[3].FeeInterest, source.blocks[3].FeeInterestDenominator = 1, 2
+	source.blocks[4].Signer, source.blocks[4].TotalFee = other, 40
+	source.blocks[5].Timestamp = &sdk.Timestamp{Time: testStart.Add(25 * time.Hour)}
+
+	analyzer := NewAnalyzer(source, Config{BeneficiaryPercentage: 25})
+
+	report, err := analyzer.HarvestingRewards(context.Background(), testHarvester, 1, 5, testRemote)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, report.Blocks)
+	assert.Equal(t, 4, report.SignedBlocks)
+	assert.Equal(t, 5, report.BeneficiaryBlocks)
+	assert.Equal(t, sdk.Amount(640), report.TotalFee)
+	assert.Equal(t, sdk.Amount(590), report.CollectedFee)
+	// block 5 is signed by remote account, so harvester receives both shares of it
+	assert.Equal(t, sdk.Amount(475), report.SignerReward)
+	assert.Equal(t, sdk.Amount(85), report.BeneficiaryReward)
+	assert.Equal(t, sdk.Amount(560), report.Reward)
+
+	assert.Len(t, report.Days, 2)
+	assert.Equal(t, &DailyRewards{
+		Day:               testStart,
+		SignedBlocks:      3,
+		BeneficiaryBlocks: 4,
+		TotalFee:          340,
+		CollectedFee:      290,
+		Reward:            260,
+	}, report.Days[0])
+	assert.Equal(t, testStart.Add(24*time.Hour), report.Days[1].Day)
+
+	buf := &bytes.Buffer{}
+	assert.Nil(t, report.WriteCSV(buf))
+	assert.Equal(t, "day,signed_blocks,beneficiary_blocks,total_fee,collected_fee,reward\n"+
+		"2020-01-01,3,4,340,290,260\n"+
+		"2020-01-02,1,1,300,300,300\n", buf.String())
+
+	// without remote account its blocks are counted only as beneficiary blocks
+	report, err = analyzer.HarvestingRewards(context.Background(), testHarvester, 1, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, report.SignedBlocks)
+	assert.Equal(t, sdk.Amount(250), report.SignerReward)
+	assert.Equal(t, sdk.Amount(85), report.BeneficiaryReward)
+
+	_, err = NewAnalyzer(source, Config{BeneficiaryPercentage: 101}).HarvestingRewards(context.Background(), testHarvester, 1, 5)
+	assert.Equal(t, ErrInvalidBeneficiaryPercentage, err)
+}
+
+func TestPercentOf(t *testing.T) {
+	assert.Equal(t, sdk.Amount(72), percentOf(290, 25))
+	// product of amount and percentage doesn't fit into int64
+	assert.Equal(t, sdk.Amount(math.MaxInt64/4), percentOf(math.MaxInt64, 25))
+	assert.Equal(t, sdk.Amount(math.MaxInt64), percentOf(math.MaxInt64, 100))
+	assert.Equal(t, sdk.Amount(0), percentOf(-10, 25))
+}
+
+func TestLinkedRemote(t *testing.T) {
+	source := &fakeAccountInfoSource{}
+
+	remote, err := LinkedRemote(context.Background(), source, testHarvester)
+	assert.Nil(t, err)
+	assert.Nil(t, remote)
+
+	source.info = &sdk.AccountInfo{AccountType: sdk.MainAccount, LinkedAccount: testRemote}
+	remote, err = LinkedRemote(context.Background(), source, testHarvester)
+	assert.Nil(t, err)
+	assert.Equal(t, testRemote, remote)
+}
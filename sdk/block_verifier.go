@@ -0,0 +1,313 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-crypto"
+)
+
+const (
+	FeeMultiplierSize = 4
+	FeeInterestSize   = 4
+	// Size, Signature, Signer, Version, Type, Height, Timestamp, Difficulty, FeeMultiplier, PreviousBlockHash,
+	// BlockTransactionsHash, BlockReceiptsHash, StateHash, Beneficiary, FeeInterest, FeeInterestDenominator
+	BlockHeaderSize = SizeSize + SignatureSize + SignerSize + VersionSize + TypeSize + 3*BaseInt64Size +
+		FeeMultiplierSize + 4*Hash256 + KeySize + 2*FeeInterestSize
+)
+
+// returns block header serialized as it is signed by harvester
+func (b *BlockInfo) HeaderBytes() ([]byte, error) {
+	if b.Signer == nil || b.Timestamp == nil {
+		return nil, ErrIncompleteBlockHeader
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, BlockHeaderSize))
+
+	write := func(v interface{}) {
+		_ = binary.Write(buf, binary.LittleEndian, v)
+	}
+
+	writeKey := func(account *PublicAccount) error {
+		key := make([]byte, KeySize)
+		if account != nil {
+			raw, err := hex.DecodeString(account.PublicKey)
+			if err != nil {
+				return err
+			}
+			copy(key, raw)
+		}
+		buf.Write(key)
+		return nil
+	}
+
+	writeHash := func(h *Hash) {
+		if h == nil {
+			h = &Hash{}
+		}
+		buf.Write(h[:])
+	}
+
+	write(uint32(BlockHeaderSize))
+
+	signature := Signature{}
+	if b.Signature != nil {
+		signature = *b.Signature
+	}
+	buf.Write(signature[:])
+
+	if err := writeKey(b.Signer); err != nil {
+		return nil, err
+	}
+
+	write(uint32(b.NetworkType)<<24 | uint32(b.Version))
+	write(uint16(b.Type))
+	write(uint64(b.Height))
+	write(uint64(b.Timestamp.ToBlockchainTimestamp().baseInt64))
+	write(uint64(b.Difficulty))
+	write(b.FeeMultiplier)
+	writeHash(b.PreviousBlockHash)
+	writeHash(b.BlockTransactionsHash)
+	writeHash(b.BlockReceiptsHash)
+	writeHash(b.StateHash)
+
+	if err := writeKey(b.Beneficiary); err != nil {
+		return nil, err
+	}
+
+	write(b.FeeInterest)
+	write(b.FeeInterestDenominator)
+
+	return buf.Bytes(), nil
+}
+
+// returns hash of block calculated from its header
+func (b *BlockInfo) CalculateHash() (*Hash, error) {
+	header, err := b.HeaderBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	return createTransactionHash(header, nil)
+}
+
+// returns generation hash of block which follows the block with previousGenerationHash and is harvested by signer
+func CalculateGenerationHash(previousGenerationHash *Hash, signer *PublicAccount) (*Hash, error) {
+	if previousGenerationHash == nil {
+		return nil, ErrNilHash
+	}
+
+	if signer == nil {
+		return nil, ErrNilAccount
+	}
+
+	key, err := hex.DecodeString(signer.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := crypto.HashesSha3_256(append(previousGenerationHash[:], key...))
+	if err != nil {
+		return nil, err
+	}
+
+	return bytesToHash(r)
+}
+
+// checks Signature of block against Signer over serialized header and that BlockHash matches the header
+func VerifyBlockHeader(b *BlockInfo) error {
+	if b == nil || b.Signature == nil {
+		return ErrIncompleteBlockHeader
+	}
+
+	header, err := b.HeaderBytes()
+	if err != nil {
+		return err
+	}
+
+	key, err := hex.DecodeString(b.Signer.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	kp, err := crypto.NewKeyPair(nil, crypto.NewPublicKey(key), nil)
+	if err != nil {
+		return err
+	}
+
+	signature, err := crypto.NewSignatureFromBytes(b.Signature[:])
+	if err != nil {
+		return err
+	}
+
+	if !crypto.NewSignerFromKeyPair(kp, nil).Verify(header[SizeSize+SignatureSize+SignerSize:], signature) {
+		return ErrInvalidBlockSignature
+	}
+
+	if b.BlockHash != nil {
+		hash, err := createTransactionHash(header, nil)
+		if err != nil {
+			return err
+		}
+
+		if !hash.Equal(b.BlockHash) {
+			return ErrBlockHashMismatch
+		}
+	}
+
+	return nil
+}
+
+// checks that next block follows previous one: height, PreviousBlockHash, generation hash and timestamp
+func VerifyBlockLink(previous, next *BlockInfo) error {
+	if previous == nil || next == nil || previous.Timestamp == nil || next.Timestamp == nil {
+		return ErrIncompleteBlockHeader
+	}
+
+	if next.Height != previous.Height+1 {
+		return ErrBlockHeightLink
+	}
+
+	if previous.BlockHash == nil || next.PreviousBlockHash == nil || !previous.BlockHash.Equal(next.PreviousBlockHash) {
+		return ErrPreviousBlockHashLink
+	}
+
+	if previous.GenerationHash == nil || next.GenerationHash == nil {
+		return ErrGenerationHashLink
+	}
+
+	generationHash, err := CalculateGenerationHash(previous.GenerationHash, next.Signer)
+	if err != nil {
+		return err
+	}
+
+	if !generationHash.Equal(next.GenerationHash) {
+		return ErrGenerationHashLink
+	}
+
+	if !next.Timestamp.After(previous.Timestamp.Time) {
+		return ErrBlockTimestampOrder
+	}
+
+	return nil
+}
+
+// checks every header and links between consecutive headers
+func VerifyBlockHeaders(headers ...*BlockInfo) error {
+	for i, h := range headers {
+		if err := VerifyBlockHeader(h); err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if err := VerifyBlockLink(headers[i-1], h); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// HeaderChain keeps chain of verified block headers starting from trusted checkpoint
+type HeaderChain struct {
+	sync.RWMutex
+	headers map[Height]*BlockInfo
+	tip     *BlockInfo
+}
+
+// returns HeaderChain which trusts passed checkpoint without verification
+func NewHeaderChain(checkpoint *BlockInfo) (*HeaderChain, error) {
+	if checkpoint == nil || checkpoint.BlockHash == nil || checkpoint.GenerationHash == nil {
+		return nil, ErrIncompleteBlockHeader
+	}
+
+	return &HeaderChain{
+		headers: map[Height]*BlockInfo{checkpoint.Height: checkpoint},
+		tip:     checkpoint,
+	}, nil
+}
+
+// returns last verified header
+func (c *HeaderChain) Tip() *BlockInfo {
+	c.RLock()
+	defer c.RUnlock()
+	return c.tip
+}
+
+// returns verified header at passed height
+func (c *HeaderChain) Header(height Height) (*BlockInfo, bool) {
+	c.RLock()
+	defer c.RUnlock()
+	h, ok := c.headers[height]
+	return h, ok
+}
+
+// verifies headers following the tip and appends them. Nothing is appended if any header is invalid
+func (c *HeaderChain) Append(headers ...*BlockInfo) error {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(headers) == 0 {
+		return nil
+	}
+
+	if err := VerifyBlockHeaders(headers...); err != nil {
+		return err
+	}
+
+	if err := VerifyBlockLink(c.tip, headers[0]); err != nil {
+		return err
+	}
+
+	for _, h := range headers {
+		c.headers[h.Height] = h
+	}
+	c.tip = headers[len(headers)-1]
+
+	return nil
+}
+
+// requests headers from the tip to current chain height and appends them. Returns new tip height
+func (c *HeaderChain) Sync(ctx context.Context, blockchain *BlockchainService, pageSize Amount) (Height, error) {
+	height, err := blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for tip := c.Tip().Height; tip < height; tip = c.Tip().Height {
+		page, err := blockchain.GetBlocksByHeightWithLimit(ctx, tip+1, pageSize)
+		if err != nil {
+			return tip, err
+		}
+
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].Height < page[j].Height
+		})
+
+		headers := make([]*BlockInfo, 0, len(page))
+		for _, b := range page {
+			if b.Height > tip && b.Height <= height {
+				headers = append(headers, b)
+			}
+		}
+
+		if len(headers) == 0 {
+			return tip, ErrResourceNotFound
+		}
+
+		if err := c.Append(headers...); err != nil {
+			return tip, err
+		}
+	}
+
+	return c.Tip().Height, nil
+}
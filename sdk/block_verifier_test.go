@@ -0,0 +1,165 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/proximax-storage/go-xpx-crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func signTestBlock(t *testing.T, harvester *Account, b *BlockInfo) {
+	b.Signer = harvester.PublicAccount
+
+	header, err := b.HeaderBytes()
+	assert.Nil(t, err)
+
+	signature, err := crypto.NewSignerFromKeyPair(harvester.KeyPair, nil).Sign(header[SizeSize+SignatureSize+SignerSize:])
+	assert.Nil(t, err)
+
+	b.Signature = &Signature{}
+	copy(b.Signature[:], signature.Bytes())
+
+	b.BlockHash, err = b.CalculateHash()
+	assert.Nil(t, err)
+}
+
+func testHeaderChain(t *testing.T, count int) []*BlockInfo {
+	harvester, err := NewAccount(MijinTest, nil)
+	assert.Nil(t, err)
+
+	blocks := make([]*BlockInfo, 0, count)
+	for i := 0; i < count; i++ {
+		b := &BlockInfo{
+			NetworkType:            MijinTest,
+			Version:                3,
+			Type:                   uint64(Block),
+			Height:                 Height(100 + i),
+			Timestamp:              NewTimestamp(time.Date(2020, 1, 1, 0, 0, 15*i, 0, time.UTC).UnixNano() / int64(time.Millisecond)),
+			Difficulty:             Difficulty(100000000000000),
+			FeeMultiplier:          10,
+			BlockTransactionsHash:  &Hash{1},
+			BlockReceiptsHash:      &Hash{2},
+			StateHash:              &Hash{3},
+			FeeInterest:            1,
+			FeeInterestDenominator: 2,
+		}
+
+		if i == 0 {
+			b.PreviousBlockHash = &Hash{4}
+			b.GenerationHash = &Hash{5}
+		} else {
+			b.PreviousBlockHash = blocks[i-1].BlockHash
+			b.GenerationHash, err = CalculateGenerationHash(blocks[i-1].GenerationHash, harvester.PublicAccount)
+			assert.Nil(t, err)
+		}
+
+		signTestBlock(t, harvester, b)
+		blocks = append(blocks, b)
+	}
+
+	return blocks
+}
+
+func TestBlockInfo_HeaderBytes(t *testing.T) {
+	blocks := testHeaderChain(t, 1)
+
+	header, err := blocks[0].HeaderBytes()
+	assert.Nil(t, err)
+	assert.Len(t, header, BlockHeaderSize)
+
+	_, err = (&BlockInfo{}).HeaderBytes()
+	assert.Equal(t, ErrIncompleteBlockHeader, err)
+}
+
+// checks offsets of header fields against BlockHeader layout of catapult schema instead of the serializer itself
+func TestBlockInfo_HeaderBytesLayout(t *testing.T) {
+	b := testHeaderChain(t, 1)[0]
+	b.Beneficiary = b.Signer
+
+	header, err := b.HeaderBytes()
+	assert.Nil(t, err)
+
+	signer, err := hex.DecodeString(b.Signer.PublicKey)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "2e010000", hex.EncodeToString(header[0:4]))
+	assert.Equal(t, b.Signature[:], header[4:68])
+	assert.Equal(t, signer, header[68:100])
+	// version 3 of MijinTest network, Block type
+	assert.Equal(t, "03000090", hex.EncodeToString(header[100:104]))
+	assert.Equal(t, "4381", hex.EncodeToString(header[104:106]))
+	assert.Equal(t, "6400000000000000", hex.EncodeToString(header[106:114]))
+	assert.Equal(t, b.Timestamp.ToBlockchainTimestamp().baseInt64, baseInt64(binary.LittleEndian.Uint64(header[114:122])))
+	assert.Equal(t, "00407a10f35a0000", hex.EncodeToString(header[122:130]))
+	assert.Equal(t, "0a000000", hex.EncodeToString(header[130:134]))
+	assert.Equal(t, b.PreviousBlockHash[:], header[134:166])
+	assert.Equal(t, b.BlockTransactionsHash[:], header[166:198])
+	assert.Equal(t, b.BlockReceiptsHash[:], header[198:230])
+	assert.Equal(t, b.StateHash[:], header[230:262])
+	assert.Equal(t, signer, header[262:294])
+	assert.Equal(t, "0100000002000000", hex.EncodeToString(header[294:302]))
+}
+
+func TestVerifyBlockHeaders(t *testing.T) {
+	blocks := testHeaderChain(t, 4)
+
+	assert.Nil(t, VerifyBlockHeaders(blocks...))
+
+	tampered := *blocks[2]
+	tampered.StateHash = &Hash{9}
+	assert.Equal(t, ErrInvalidBlockSignature, VerifyBlockHeader(&tampered))
+
+	tampered = *blocks[2]
+	tampered.BlockHash = &Hash{9}
+	assert.Equal(t, ErrBlockHashMismatch, VerifyBlockHeader(&tampered))
+
+	assert.Equal(t, ErrBlockHeightLink, VerifyBlockLink(blocks[0], blocks[2]))
+
+	tampered = *blocks[1]
+	tampered.PreviousBlockHash = &Hash{9}
+	assert.Equal(t, ErrPreviousBlockHashLink, VerifyBlockLink(blocks[0], &tampered))
+
+	tampered = *blocks[1]
+	tampered.GenerationHash = &Hash{9}
+	assert.Equal(t, ErrGenerationHashLink, VerifyBlockLink(blocks[0], &tampered))
+
+	tampered = *blocks[1]
+	tampered.Timestamp = blocks[0].Timestamp
+	assert.Equal(t, ErrBlockTimestampOrder, VerifyBlockLink(blocks[0], &tampered))
+}
+
+func TestHeaderChain_Append(t *testing.T) {
+	blocks := testHeaderChain(t, 5)
+
+	chain, err := NewHeaderChain(blocks[0])
+	assert.Nil(t, err)
+
+	assert.Nil(t, chain.Append(blocks[1:3]...))
+	assert.Equal(t, blocks[2], chain.Tip())
+
+	// block 4 doesn't follow the tip
+	assert.Equal(t, ErrBlockHeightLink, chain.Append(blocks[4]))
+
+	tampered := *blocks[4]
+	tampered.FeeMultiplier = 20
+	assert.Equal(t, ErrInvalidBlockSignature, chain.Append(blocks[3], &tampered))
+	assert.Equal(t, blocks[2], chain.Tip())
+
+	_, ok := chain.Header(blocks[3].Height)
+	assert.False(t, ok)
+
+	assert.Nil(t, chain.Append(blocks[3:]...))
+	h, ok := chain.Header(blocks[3].Height)
+	assert.True(t, ok)
+	assert.Equal(t, blocks[3], h)
+
+	_, err = NewHeaderChain(&BlockInfo{})
+	assert.Equal(t, ErrIncompleteBlockHeader, err)
+}
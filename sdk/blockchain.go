@@ -86,6 +86,32 @@ func (b *BlockchainService) GetBlocksByHeightWithLimit(ctx context.Context, heig
 	return dtos.toStruct()
 }
 
+// returns merkle path from MerkleComponentHash of transaction with passed hash to BlockTransactionsHash of block
+func (b *BlockchainService) GetTransactionMerklePath(ctx context.Context, height Height, hash *Hash) ([]*MerklePathItem, error) {
+	if height == 0 {
+		return nil, ErrNilOrZeroHeight
+	}
+
+	if hash == nil {
+		return nil, ErrNilHash
+	}
+
+	url := net.NewUrl(fmt.Sprintf(blockTransactionMerkleRoute, height, hash))
+
+	dto := &merkleProofDTO{}
+
+	resp, err := b.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handleResponseStatusCode(resp, map[int]error{404: ErrResourceNotFound, 409: ErrArgumentNotValid}); err != nil {
+		return nil, err
+	}
+
+	return dto.toStruct()
+}
+
 func (b *BlockchainService) GetBlockchainHeight(ctx context.Context) (Height, error) {
 	bh := &struct {
 		Height uint64DTO `json:"height"`
@@ -131,3 +131,40 @@ func (b *blockInfoDTOs) toStruct() ([]*BlockInfo, error) {
 
 	return blocks, nil
 }
+
+type merklePathItemDTO struct {
+	Position string  `json:"position"`
+	Hash     hashDto `json:"hash"`
+}
+
+type merkleProofDTO struct {
+	MerklePath []*merklePathItemDTO `json:"merklePath"`
+}
+
+func (dto *merkleProofDTO) toStruct() ([]*MerklePathItem, error) {
+	path := make([]*MerklePathItem, len(dto.MerklePath))
+
+	for i, item := range dto.MerklePath {
+		hash, err := item.Hash.Hash()
+		if err != nil {
+			return nil, err
+		}
+
+		var position MerklePosition
+		switch item.Position {
+		case "left":
+			position = MerkleLeft
+		case "right":
+			position = MerkleRight
+		default:
+			return nil, ErrUnknownMerklePosition
+		}
+
+		path[i] = &MerklePathItem{
+			Position: position,
+			Hash:     hash,
+		}
+	}
+
+	return path, nil
+}
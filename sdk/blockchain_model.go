@@ -71,3 +71,37 @@ func (b *BlockchainStorageInfo) String() string {
 		str.NewField("NumAccounts", str.IntPattern, b.NumAccounts),
 	)
 }
+
+// MerklePosition defines side of sibling hash in merkle path
+type MerklePosition uint8
+
+// MerklePosition enums
+const (
+	MerkleLeft MerklePosition = iota + 1
+	MerkleRight
+)
+
+func (p MerklePosition) String() string {
+	switch p {
+	case MerkleLeft:
+		return "left"
+	case MerkleRight:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// MerklePathItem is a sibling hash on the way from leaf to merkle root
+type MerklePathItem struct {
+	Position MerklePosition
+	Hash     *Hash
+}
+
+func (m *MerklePathItem) String() string {
+	return str.StructToString(
+		"MerklePathItem",
+		str.NewField("Position", str.StringPattern, m.Position),
+		str.NewField("Hash", str.StringPattern, m.Hash),
+	)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/proximax-storage/go-xpx-utils/mock"
 	"github.com/proximax-storage/go-xpx-utils/tests"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/sha3"
 )
 
 // Mock response for TestBlockchainService_GetBlockHeight & GetBlockInfo
@@ -240,3 +241,39 @@ func TestBlockchainService_GetBlockTransactions(t *testing.T) {
 		assert.Equal(t, wantBlockTransactions[key].GetAbstractTransaction().Signature, transaction.GetAbstractTransaction().Signature)
 	}
 }
+
+func testMerkleHash(left, right *Hash) *Hash {
+	h := sha3.New256()
+	h.Write(left[:])
+	h.Write(right[:])
+
+	res := &Hash{}
+	copy(res[:], h.Sum(nil))
+	return res
+}
+
+func TestBlockchainService_GetTransactionMerklePath(t *testing.T) {
+	leafs := []*Hash{{1}, {2}, {3}}
+	// odd level duplicates the last hash
+	left := testMerkleHash(leafs[0], leafs[1])
+	right := testMerkleHash(leafs[2], leafs[2])
+	block := &BlockInfo{BlockTransactionsHash: testMerkleHash(left, right)}
+
+	mockServer.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(blockTransactionMerkleRoute, testHeight, leafs[2]),
+		RespBody: fmt.Sprintf(`{"merklePath": [{"position": "right", "hash": "%s"}, {"position": "left", "hash": "%s"}]}`,
+			leafs[2], left),
+	})
+
+	path, err := blockClient.GetTransactionMerklePath(ctx, testHeight, leafs[2])
+	assert.Nilf(t, err, "GetTransactionMerklePath returned error: %s", err)
+	assert.Equal(t, []*MerklePathItem{{MerkleRight, leafs[2]}, {MerkleLeft, left}}, path)
+
+	info := &TransactionInfo{MerkleComponentHash: leafs[2]}
+	assert.Nil(t, VerifyTransactionMerklePath(info, path, block))
+
+	info.MerkleComponentHash = leafs[1]
+	assert.Equal(t, ErrMerkleRootMismatch, VerifyTransactionMerklePath(info, path, block))
+
+	assert.Nil(t, VerifyMerklePath(leafs[0], nil, leafs[0]))
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// number of recent blocks used to estimate block time
+const defaultClockSampleSize Amount = 100
+
+// ChainClock converts between heights, block durations and wall-clock time.
+// Times of observed blocks are exact, other heights are estimated with BlockTime from the nearest observed block
+type ChainClock struct {
+	blocks []*BlockInfo
+	// Target time between blocks from network config
+	TargetBlockTime time.Duration
+	// Average time between observed blocks or TargetBlockTime if less than two blocks are observed
+	BlockTime time.Duration
+}
+
+// returns clock from observed blocks and target time between blocks. Blocks without timestamp are ignored
+func NewChainClock(blocks []*BlockInfo, targetBlockTime time.Duration) (*ChainClock, error) {
+	if targetBlockTime <= 0 {
+		return nil, ErrInvalidBlockTime
+	}
+
+	observed := make([]*BlockInfo, 0, len(blocks))
+	for _, b := range blocks {
+		if b != nil && b.Timestamp != nil {
+			observed = append(observed, b)
+		}
+	}
+
+	if len(observed) == 0 {
+		return nil, ErrNoObservedBlocks
+	}
+
+	sort.Slice(observed, func(i, j int) bool {
+		return observed[i].Height < observed[j].Height
+	})
+
+	c := &ChainClock{
+		blocks:          observed,
+		TargetBlockTime: targetBlockTime,
+		BlockTime:       targetBlockTime,
+	}
+
+	first, last := observed[0], observed[len(observed)-1]
+	if last.Height > first.Height && last.Timestamp.After(first.Timestamp.Time) {
+		c.BlockTime = last.Timestamp.Sub(first.Timestamp.Time) / time.Duration(last.Height-first.Height)
+	}
+
+	return c, nil
+}
+
+// returns clock built from the last blocks of chain and block generation time of the current config
+func (c *Client) NewChainClock(ctx context.Context) (*ChainClock, error) {
+	target, err := c.BlockGenerationTime(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := c.Blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	from := Height(1)
+	if height > Height(defaultClockSampleSize) {
+		from = height - Height(defaultClockSampleSize) + 1
+	}
+
+	blocks, err := c.Blockchain.GetBlocksByHeightWithLimit(ctx, from, defaultClockSampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewChainClock(blocks, target)
+}
+
+// returns the highest observed height
+func (c *ChainClock) Tip() Height {
+	return c.blocks[len(c.blocks)-1].Height
+}
+
+// returns time of block at passed height. Heights between observed blocks are interpolated
+func (c *ChainClock) TimeAt(height Height) time.Time {
+	first, last := c.blocks[0], c.blocks[len(c.blocks)-1]
+
+	switch {
+	case height >= last.Height:
+		return last.Timestamp.Add(c.ToDuration(height - last.Height))
+	case height <= first.Height:
+		return first.Timestamp.Add(-c.ToDuration(first.Height - height))
+	}
+
+	i := sort.Search(len(c.blocks), func(i int) bool {
+		return c.blocks[i].Height >= height
+	})
+
+	next := c.blocks[i]
+	if next.Height == height {
+		return next.Timestamp.Time
+	}
+
+	previous := c.blocks[i-1]
+	span := next.Timestamp.Sub(previous.Timestamp.Time)
+	return previous.Timestamp.Add(span * time.Duration(height-previous.Height) / time.Duration(next.Height-previous.Height))
+}
+
+// returns height of the last block produced at or before passed time. The result is never less than 1.
+// Times between observed blocks are interpolated the same way as in TimeAt
+func (c *ChainClock) HeightAt(t time.Time) Height {
+	first, last := c.blocks[0], c.blocks[len(c.blocks)-1]
+
+	switch {
+	case !t.Before(last.Timestamp.Time):
+		return last.Height + Height(t.Sub(last.Timestamp.Time)/c.BlockTime)
+	case t.Before(first.Timestamp.Time):
+		behind := c.ToBlocks(first.Timestamp.Sub(t))
+		if behind >= first.Height {
+			return 1
+		}
+		return first.Height - behind
+	}
+
+	i := sort.Search(len(c.blocks), func(i int) bool {
+		return c.blocks[i].Timestamp.After(t)
+	})
+
+	previous, next := c.blocks[i-1], c.blocks[i]
+	span := next.Timestamp.Sub(previous.Timestamp.Time)
+	if span <= 0 {
+		return previous.Height
+	}
+
+	return previous.Height + Height(t.Sub(previous.Timestamp.Time)*time.Duration(next.Height-previous.Height)/span)
+}
+
+// returns number of blocks produced during passed time, rounded up
+func (c *ChainClock) ToBlocks(d time.Duration) Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return Duration((d + c.BlockTime - 1) / c.BlockTime)
+}
+
+// returns time needed to produce passed number of blocks
+func (c *ChainClock) ToDuration(blocks Duration) time.Duration {
+	return time.Duration(blocks) * c.BlockTime
+}
+
+// returns number of blocks from the tip till passed time, e.g. for lock or rental duration. Past time gives zero
+func (c *ChainClock) BlocksUntil(t time.Time) Duration {
+	return c.ToBlocks(t.Sub(c.TimeAt(c.Tip())))
+}
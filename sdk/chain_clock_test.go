@@ -0,0 +1,80 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testClockStart = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// blocks at heights 100, 101 and 104 produced every 10 seconds
+func testClock(t *testing.T) *ChainClock {
+	blocks := []*BlockInfo{
+		{Height: 104, Timestamp: &Timestamp{testClockStart.Add(40 * time.Second)}},
+		{Height: 100, Timestamp: &Timestamp{testClockStart}},
+		{Height: 101, Timestamp: &Timestamp{testClockStart.Add(10 * time.Second)}},
+		{Height: 105},
+	}
+
+	clock, err := NewChainClock(blocks, 15*time.Second)
+	assert.Nil(t, err)
+
+	return clock
+}
+
+func TestChainClock_TimeAt(t *testing.T) {
+	clock := testClock(t)
+
+	assert.Equal(t, 10*time.Second, clock.BlockTime)
+	assert.Equal(t, Height(104), clock.Tip())
+
+	assert.Equal(t, testClockStart.Add(10*time.Second), clock.TimeAt(101))
+	assert.Equal(t, testClockStart.Add(20*time.Second), clock.TimeAt(102))
+	assert.Equal(t, testClockStart.Add(100*time.Second), clock.TimeAt(110))
+	assert.Equal(t, testClockStart.Add(-30*time.Second), clock.TimeAt(97))
+}
+
+func TestChainClock_HeightAt(t *testing.T) {
+	clock := testClock(t)
+
+	assert.Equal(t, Height(100), clock.HeightAt(testClockStart))
+	assert.Equal(t, Height(101), clock.HeightAt(testClockStart.Add(19*time.Second)))
+	// heights between observed blocks 101 and 104 are interpolated
+	assert.Equal(t, Height(102), clock.HeightAt(testClockStart.Add(25*time.Second)))
+	assert.Equal(t, Height(103), clock.HeightAt(testClockStart.Add(39*time.Second)))
+	for h := Height(100); h <= 110; h++ {
+		assert.Equal(t, h, clock.HeightAt(clock.TimeAt(h)))
+	}
+	assert.Equal(t, Height(104), clock.HeightAt(testClockStart.Add(49*time.Second)))
+	assert.Equal(t, Height(110), clock.HeightAt(testClockStart.Add(100*time.Second)))
+	assert.Equal(t, Height(97), clock.HeightAt(testClockStart.Add(-30*time.Second)))
+	assert.Equal(t, Height(1), clock.HeightAt(testClockStart.Add(-24*time.Hour)))
+}
+
+func TestChainClock_Durations(t *testing.T) {
+	clock := testClock(t)
+
+	assert.Equal(t, Duration(360), clock.ToBlocks(time.Hour))
+	assert.Equal(t, Duration(2), clock.ToBlocks(11*time.Second))
+	assert.Equal(t, Duration(0), clock.ToBlocks(-time.Second))
+	assert.Equal(t, time.Minute, clock.ToDuration(6))
+	assert.Equal(t, Duration(6), clock.BlocksUntil(testClockStart.Add(100*time.Second)))
+}
+
+func TestNewChainClock_TargetBlockTime(t *testing.T) {
+	clock, err := NewChainClock([]*BlockInfo{{Height: 10, Timestamp: &Timestamp{testClockStart}}}, 15*time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Second, clock.BlockTime)
+
+	_, err = NewChainClock([]*BlockInfo{{Height: 10}}, 15*time.Second)
+	assert.Equal(t, ErrNoObservedBlocks, err)
+
+	_, err = NewChainClock(nil, 0)
+	assert.Equal(t, ErrInvalidBlockTime, err)
+}
@@ -57,12 +57,19 @@ const (
 
 // routes for BlockchainService
 const (
-	blockHeightRoute         = "/chain/height"
-	blockByHeightRoute       = "/block/%s"
-	blockScoreRoute          = "/chain/score"
-	blockGetTransactionRoute = "/block/%s/transactions"
-	blockInfoRoute           = "/blocks/%s/limit/%s"
-	blockStorageRoute        = "/diagnostic/storage"
+	blockHeightRoute            = "/chain/height"
+	blockByHeightRoute          = "/block/%s"
+	blockScoreRoute             = "/chain/score"
+	blockGetTransactionRoute    = "/block/%s/transactions"
+	blockInfoRoute              = "/blocks/%s/limit/%s"
+	blockStorageRoute           = "/diagnostic/storage"
+	blockTransactionMerkleRoute = "/block/%s/transaction/%s/merkle"
+)
+
+// routes for ReceiptService
+const (
+	blockReceiptsRoute      = "/block/%s/receipts"
+	blockReceiptMerkleRoute = "/block/%s/receipt/%s/merkle"
 )
 
 // routes for ContractsService
@@ -44,6 +44,7 @@ var (
 	ErrUnknownBlockchainType  = errors.New("Not supported Blockchain Type")
 	ErrInvalidHashLength      = errors.New("The length of Hash is invalid")
 	ErrInvalidSignatureLength = errors.New("The length of Signature is invalid")
+	ErrInvalidSignerLength    = errors.New("The length of Signer is invalid")
 )
 
 // Mosaic errors
@@ -66,8 +67,55 @@ var (
 
 // Blockchain errors
 var (
-	ErrNilOrZeroHeight = errors.New("block height should not be nil or zero")
-	ErrNilOrZeroLimit  = errors.New("limit should not be nil or zero")
+	ErrNilOrZeroHeight       = errors.New("block height should not be nil or zero")
+	ErrNilOrZeroLimit        = errors.New("limit should not be nil or zero")
+	ErrUnknownMerklePosition = errors.New("unknown position of merkle path item")
+	ErrMerkleRootMismatch    = errors.New("merkle path doesn't lead to expected root")
+	ErrIncompleteBlockHeader = errors.New("block header should contain signature, signer, hashes and timestamp")
+	ErrInvalidBlockSignature = errors.New("block signature is not valid for signer")
+	ErrBlockHashMismatch     = errors.New("block hash doesn't match block header")
+	ErrBlockHeightLink       = errors.New("block height doesn't follow previous block")
+	ErrPreviousBlockHashLink = errors.New("previous block hash doesn't match hash of previous block")
+	ErrGenerationHashLink    = errors.New("generation hash doesn't follow previous block")
+	ErrBlockTimestampOrder   = errors.New("block timestamp should be greater than timestamp of previous block")
+	ErrNoObservedBlocks      = errors.New("at least one block with timestamp should be observed")
+	ErrInvalidBlockTime      = errors.New("block time should be greater than zero")
+)
+
+// Receipt errors
+var (
+	ErrResolutionNotFound = errors.New("resolution of alias is not found in block statement")
+)
+
+// Multisig errors
+var (
+	ErrNotMultisigAccount       = errors.New("account is not multisig")
+	ErrNoEligibleSigners        = errors.New("none of available accounts is a cosignatory of multisig account")
+	ErrNilMultisigSetup         = errors.New("multisig setup should not be nil")
+	ErrMultisigThresholdTooHigh = errors.New("multisig threshold should not be greater than number of cosignatories")
+	ErrMultisigThresholdTooLow  = errors.New("multisig threshold should be at least 1 when account has cosignatories")
+	ErrDuplicateCosignatory     = errors.New("cosignatory is duplicated")
+	ErrMultisigCycle            = errors.New("cosignatory would make a cycle in multisig graph")
+	ErrMultisigDeltaOverflow    = errors.New("delta of multisig threshold doesn't fit into int8")
+)
+
+// Account properties errors
+var (
+	ErrNilAccountPropertiesSetup = errors.New("account properties setup should not be nil")
+	ErrPropertyModeConflict      = errors.New("values of the same kind can't be allowed and blocked at once")
+	ErrDuplicatePropertyValue    = errors.New("account property value is duplicated")
+	ErrPropertyOwnAddress        = errors.New("account properties can't contain address of account itself")
+	ErrPropertySelfBlocking      = errors.New("account properties can't block changes of entity type properties")
+)
+
+// Harvesting errors
+var (
+	ErrRemoteKeyNotFound      = errors.New("key of remote account is not found")
+	ErrRemoteKeyMismatch      = errors.New("key of remote account doesn't belong to account")
+	ErrAccountNotLinked       = errors.New("account is not linked to remote account")
+	ErrAccountAlreadyLinked   = errors.New("account is already linked")
+	ErrLinkTransactionFailed  = errors.New("link transaction is rejected by node")
+	ErrLinkTransactionExpired = errors.New("link transaction is not confirmed before its deadline")
 )
 
 // Lock errors
@@ -83,8 +131,22 @@ var (
 	ErrNilHash           = errors.New("hash is nil")
 	ErrBlankAddress      = errors.New("address is blank")
 	ErrNilAccount        = errors.New("account should not be nil")
+	ErrNilTransaction    = errors.New("transaction should not be nil")
 	ErrInvalidAddress    = errors.New("wrong address")
 	ErrNoChanges         = errors.New("transaction should contain changes")
+	ErrEmptyBaseURLs     = errors.New("empty base urls")
+	ErrAmountOverflow    = errors.New("amount doesn't fit into int64")
+)
+
+// Network config errors
+var (
+	ErrConfigSectionNotFound    = errors.New("section is not found in network config")
+	ErrConfigFieldNotFound      = errors.New("field is not found in network config section")
+	ErrConfigValueType          = errors.New("value of network config field has wrong type")
+	ErrNilNetworkConfig         = errors.New("network config should not be nil")
+	ErrEmptyEntityVersions      = errors.New("supported entity should have at least one version")
+	ErrNetworkConfigUnsupported = errors.New("network config transaction should stay supported")
+	ErrEntityVersionUnsupported = errors.New("network doesn't support any version of entity implemented by the SDK")
 )
 
 // reputations error
@@ -0,0 +1,427 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/proximax-storage/go-xpx-crypto"
+)
+
+const DefaultHarvestingPollInterval = 5 * time.Second
+
+// group of statuses of transactions rejected by node
+const failedTransactionGroup = "failed"
+
+// EncryptedRemoteKey is private key of remote account encrypted by main account for itself,
+// so it can be decrypted only with private key of main account
+type EncryptedRemoteKey struct {
+	MainPublicKey   string `json:"mainPublicKey"`
+	RemotePublicKey string `json:"remotePublicKey"`
+	EncryptedKey    []byte `json:"encryptedKey"`
+}
+
+// returns private key of remote account encrypted by main account
+func EncryptRemoteKey(main *Account, remote *Account) (*EncryptedRemoteKey, error) {
+	if main == nil || remote == nil {
+		return nil, ErrNilAccount
+	}
+
+	encrypted, err := crypto.NewBlockCipher(main.KeyPair, main.KeyPair, nil).Encrypt([]byte(remote.KeyPair.PrivateKey.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedRemoteKey{
+		MainPublicKey:   main.PublicAccount.PublicKey,
+		RemotePublicKey: remote.PublicAccount.PublicKey,
+		EncryptedKey:    encrypted,
+	}, nil
+}
+
+// returns remote account decrypted by main account
+func (k *EncryptedRemoteKey) Decrypt(main *Account) (*Account, error) {
+	if main == nil {
+		return nil, ErrNilAccount
+	}
+
+	if main.PublicAccount.PublicKey != k.MainPublicKey {
+		return nil, ErrRemoteKeyMismatch
+	}
+
+	privateKey, err := crypto.NewBlockCipher(main.KeyPair, main.KeyPair, nil).Decrypt(k.EncryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := NewAccountFromPrivateKey(string(privateKey), main.PublicAccount.Address.Type, main.generationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if remote.PublicAccount.PublicKey != k.RemotePublicKey {
+		return nil, ErrRemoteKeyMismatch
+	}
+
+	return remote, nil
+}
+
+// RemoteKeyStore keeps encrypted keys of remote accounts. It can be persistent to survive restarts
+type RemoteKeyStore interface {
+	SaveRemoteKey(key *EncryptedRemoteKey) error
+	// returns ErrRemoteKeyNotFound when there is no key of remote account
+	LoadRemoteKey(remotePublicKey string) (*EncryptedRemoteKey, error)
+	DeleteRemoteKey(remotePublicKey string) error
+}
+
+// MemoryRemoteKeyStore keeps encrypted keys in memory
+type MemoryRemoteKeyStore struct {
+	sync.RWMutex
+	keys map[string]*EncryptedRemoteKey
+}
+
+func NewMemoryRemoteKeyStore() *MemoryRemoteKeyStore {
+	return &MemoryRemoteKeyStore{keys: make(map[string]*EncryptedRemoteKey)}
+}
+
+func (s *MemoryRemoteKeyStore) SaveRemoteKey(key *EncryptedRemoteKey) error {
+	s.Lock()
+	defer s.Unlock()
+
+	s.keys[key.RemotePublicKey] = key
+	return nil
+}
+
+func (s *MemoryRemoteKeyStore) LoadRemoteKey(remotePublicKey string) (*EncryptedRemoteKey, error) {
+	s.RLock()
+	defer s.RUnlock()
+
+	key, ok := s.keys[remotePublicKey]
+	if !ok {
+		return nil, ErrRemoteKeyNotFound
+	}
+
+	return key, nil
+}
+
+func (s *MemoryRemoteKeyStore) DeleteRemoteKey(remotePublicKey string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.keys, remotePublicKey)
+	return nil
+}
+
+type harvestingSource interface {
+	GetAccountInfo(ctx context.Context, address *Address) (*AccountInfo, error)
+	Announce(ctx context.Context, tx *SignedTransaction) (string, error)
+	NewAccountLinkTransaction(deadline *Deadline, remoteAccount *PublicAccount, linkAction AccountLinkAction) (*AccountLinkTransaction, error)
+	GetTransactionStatus(ctx context.Context, id string) (*TransactionStatus, error)
+}
+
+type clientHarvestingSource struct {
+	*Client
+}
+
+func (s *clientHarvestingSource) GetAccountInfo(ctx context.Context, address *Address) (*AccountInfo, error) {
+	return s.Account.GetAccountInfo(ctx, address)
+}
+
+func (s *clientHarvestingSource) Announce(ctx context.Context, tx *SignedTransaction) (string, error) {
+	return s.Transaction.Announce(ctx, tx)
+}
+
+func (s *clientHarvestingSource) GetTransactionStatus(ctx context.Context, id string) (*TransactionStatus, error) {
+	return s.Transaction.GetTransactionStatus(ctx, id)
+}
+
+// HarvestingStatus compares link of account on chain with stored remote keys
+type HarvestingStatus struct {
+	AccountType AccountType
+	// Remote account linked on chain, nil when account isn't linked
+	Linked *PublicAccount
+	// True when encrypted key of linked remote account is stored
+	Stored bool
+}
+
+// returns true when account is linked and key of remote account is stored, so harvesting can be delegated to a node
+func (s *HarvestingStatus) Ready() bool {
+	return s.Linked != nil && s.Stored
+}
+
+// HarvestingManager manages lifecycle of remote account which harvests on behalf of main account.
+// Keys of remote accounts are stored encrypted by main account before they are linked
+type HarvestingManager struct {
+	Account *Account
+	// Interval between checks of link state while waiting for confirmation
+	PollInterval time.Duration
+	source       harvestingSource
+	store        RemoteKeyStore
+}
+
+// returns HarvestingManager of account using store for keys of remote accounts. Nil store means MemoryRemoteKeyStore
+func (c *Client) NewHarvestingManager(account *Account, store RemoteKeyStore) *HarvestingManager {
+	return newHarvestingManager(account, &clientHarvestingSource{c}, store)
+}
+
+func newHarvestingManager(account *Account, source harvestingSource, store RemoteKeyStore) *HarvestingManager {
+	if store == nil {
+		store = NewMemoryRemoteKeyStore()
+	}
+
+	return &HarvestingManager{
+		Account:      account,
+		PollInterval: DefaultHarvestingPollInterval,
+		source:       source,
+		store:        store,
+	}
+}
+
+// Status returns link state of account on chain and whether key of linked remote account is stored
+func (m *HarvestingManager) Status(ctx context.Context) (*HarvestingStatus, error) {
+	info, err := m.source.GetAccountInfo(ctx, m.Account.PublicAccount.Address)
+	if err == ErrResourceNotFound {
+		return &HarvestingStatus{AccountType: UnlinkedAccount}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	status := &HarvestingStatus{AccountType: info.AccountType}
+	if info.AccountType != MainAccount || info.LinkedAccount == nil {
+		return status, nil
+	}
+	status.Linked = info.LinkedAccount
+
+	_, err = m.store.LoadRemoteKey(status.Linked.PublicKey)
+	switch err {
+	case nil:
+		status.Stored = true
+	case ErrRemoteKeyNotFound:
+	default:
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// RemoteAccount returns decrypted remote account which is linked on chain
+func (m *HarvestingManager) RemoteAccount(ctx context.Context) (*Account, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Linked == nil {
+		return nil, ErrAccountNotLinked
+	}
+
+	key, err := m.store.LoadRemoteKey(status.Linked.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return key.Decrypt(m.Account)
+}
+
+// Link generates new remote account, stores its encrypted key and links it to account.
+// Returns after link is confirmed on chain, rejected by node, expired or ctx is done.
+// Stored key is deleted when link can't be confirmed anymore
+func (m *HarvestingManager) Link(ctx context.Context, deadline *Deadline) (*Account, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.AccountType != UnlinkedAccount {
+		return nil, errors.Wrapf(ErrAccountAlreadyLinked, "account type %d", status.AccountType)
+	}
+
+	remote, err := m.newRemoteAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.link(ctx, deadline, remote); err != nil {
+		return nil, err
+	}
+
+	return remote, nil
+}
+
+// Unlink unlinks remote account from account and removes its stored key after unlink is confirmed on chain
+func (m *HarvestingManager) Unlink(ctx context.Context, deadline *Deadline) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	if status.Linked == nil {
+		return ErrAccountNotLinked
+	}
+
+	return m.unlink(ctx, deadline, status.Linked)
+}
+
+// Rotate replaces linked remote account with new one in two confirmed steps, unlink of previous remote account
+// and then link of new one, because the node validates every transaction of aggregate against state before it.
+// Key of new remote account is stored before unlink and key of previous one is removed after unlink is confirmed.
+// When link fails after unlink, account stays unlinked and Link should be called again
+func (m *HarvestingManager) Rotate(ctx context.Context, deadline *Deadline) (*Account, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Linked == nil {
+		return nil, ErrAccountNotLinked
+	}
+
+	remote, err := m.newRemoteAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// link of new remote account is never announced without confirmed unlink
+	if err = m.unlink(ctx, deadline, status.Linked); err != nil {
+		return nil, m.discardRemoteKey(remote, err)
+	}
+
+	if err = m.link(ctx, deadline, remote); err != nil {
+		return nil, errors.Wrap(err, "previous remote account is already unlinked")
+	}
+
+	return remote, nil
+}
+
+// links remote account whose key is already stored and deletes the key when link can't be confirmed anymore
+func (m *HarvestingManager) link(ctx context.Context, deadline *Deadline, remote *Account) error {
+	tx, err := m.source.NewAccountLinkTransaction(deadline, remote.PublicAccount, AccountLink)
+	if err != nil {
+		return m.discardRemoteKey(remote, err)
+	}
+
+	hash, err := m.announce(ctx, tx)
+	if err != nil {
+		return m.discardRemoteKey(remote, err)
+	}
+
+	if err = m.waitLinked(ctx, remote.PublicAccount, hash, deadline); err != nil {
+		if linkFailed(err) {
+			return m.discardRemoteKey(remote, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// unlinks linked remote account and deletes its key after unlink is confirmed
+func (m *HarvestingManager) unlink(ctx context.Context, deadline *Deadline, linked *PublicAccount) error {
+	tx, err := m.source.NewAccountLinkTransaction(deadline, linked, AccountUnlink)
+	if err != nil {
+		return err
+	}
+
+	hash, err := m.announce(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	if err = m.waitLinked(ctx, nil, hash, deadline); err != nil {
+		return err
+	}
+
+	return m.store.DeleteRemoteKey(linked.PublicKey)
+}
+
+// returns new remote account whose key is already stored, so it is never lost after link
+func (m *HarvestingManager) newRemoteAccount() (*Account, error) {
+	remote, err := NewAccount(m.Account.PublicAccount.Address.Type, m.Account.generationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := EncryptRemoteKey(m.Account, remote)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.store.SaveRemoteKey(key); err != nil {
+		return nil, err
+	}
+
+	return remote, nil
+}
+
+// deletes stored key of remote account which is never going to be linked and returns cause
+func (m *HarvestingManager) discardRemoteKey(remote *Account, cause error) error {
+	if err := m.store.DeleteRemoteKey(remote.PublicAccount.PublicKey); err != nil {
+		return errors.Wrapf(cause, "deleting key of remote account: %s", err)
+	}
+
+	return cause
+}
+
+// returns hash of announced transaction
+func (m *HarvestingManager) announce(ctx context.Context, tx Transaction) (*Hash, error) {
+	signed, err := m.Account.Sign(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = m.source.Announce(ctx, signed); err != nil {
+		return nil, err
+	}
+
+	return signed.Hash, nil
+}
+
+// returns true when error of waitLinked means that announced transaction is never going to be confirmed
+func linkFailed(err error) bool {
+	cause := errors.Cause(err)
+	return cause == ErrLinkTransactionFailed || cause == ErrLinkTransactionExpired
+}
+
+// waits until remote account is linked to account on chain. Nil remote means waiting for unlink.
+// Gives up when announced transaction is rejected by node or its deadline passes by local clock
+func (m *HarvestingManager) waitLinked(ctx context.Context, remote *PublicAccount, hash *Hash, deadline *Deadline) error {
+	ticker := time.NewTicker(m.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		if (remote == nil && status.Linked == nil) ||
+			(remote != nil && status.Linked != nil && status.Linked.PublicKey == remote.PublicKey) {
+			return nil
+		}
+
+		txStatus, err := m.source.GetTransactionStatus(ctx, hash.String())
+		switch {
+		case err == nil && txStatus.Group == failedTransactionGroup:
+			return errors.Wrapf(ErrLinkTransactionFailed, "status %s", txStatus.Status)
+		case err != nil && err != ErrResourceNotFound:
+			return err
+		}
+
+		if deadline != nil && time.Now().After(deadline.Time) {
+			return ErrLinkTransactionExpired
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// applies announced link actions to account info immediately unless they are dropped.
+// Like the node, it ignores link of account which is already linked
+type fakeHarvestingSource struct {
+	info        *AccountInfo
+	pending     []*AccountLinkTransaction
+	announced   int
+	drop        bool
+	dropLinks   bool
+	announceErr error
+	// status of every announced transaction, nil means that node doesn't know it
+	status *TransactionStatus
+}
+
+func (s *fakeHarvestingSource) GetAccountInfo(context.Context, *Address) (*AccountInfo, error) {
+	if s.info == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	return s.info, nil
+}
+
+func (s *fakeHarvestingSource) Announce(context.Context, *SignedTransaction) (string, error) {
+	if s.announceErr != nil {
+		return "", s.announceErr
+	}
+
+	s.announced++
+	if s.drop {
+		s.pending = nil
+	}
+
+	for _, tx := range s.pending {
+		if s.info == nil {
+			s.info = &AccountInfo{}
+		}
+
+		if tx.LinkAction == AccountLink {
+			if !s.dropLinks && s.info.LinkedAccount == nil {
+				s.info.AccountType, s.info.LinkedAccount = MainAccount, tx.RemoteAccount
+			}
+		} else {
+			s.info.AccountType, s.info.LinkedAccount = UnlinkedAccount, nil
+		}
+	}
+	s.pending = nil
+
+	return "ok", nil
+}
+
+func (s *fakeHarvestingSource) GetTransactionStatus(context.Context, string) (*TransactionStatus, error) {
+	if s.status == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	return s.status, nil
+}
+
+func (s *fakeHarvestingSource) NewAccountLinkTransaction(deadline *Deadline, remoteAccount *PublicAccount, linkAction AccountLinkAction) (*AccountLinkTransaction, error) {
+	tx, err := NewAccountLinkTransaction(deadline, remoteAccount, linkAction, MijinTest)
+	if err != nil {
+		return nil, err
+	}
+
+	s.pending = append(s.pending, tx)
+	return tx, nil
+}
+
+var testLinkDeadline = NewDeadline(time.Hour)
+
+func TestEncryptRemoteKey(t *testing.T) {
+	main, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+	remote, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	key, err := EncryptRemoteKey(main, remote)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(key.EncryptedKey), remote.KeyPair.PrivateKey.String())
+
+	decrypted, err := key.Decrypt(main)
+	assert.Nil(t, err)
+	assert.Equal(t, remote.PublicAccount, decrypted.PublicAccount)
+
+	_, err = key.Decrypt(remote)
+	assert.Equal(t, ErrRemoteKeyMismatch, err)
+}
+
+func TestHarvestingManager(t *testing.T) {
+	main, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	source := &fakeHarvestingSource{}
+	store := NewMemoryRemoteKeyStore()
+	manager := newHarvestingManager(main, source, store)
+	manager.PollInterval = time.Millisecond
+
+	_, err = manager.RemoteAccount(ctx)
+	assert.Equal(t, ErrAccountNotLinked, err)
+
+	remote, err := manager.Link(ctx, testLinkDeadline)
+	assert.Nil(t, err)
+
+	status, err := manager.Status(ctx)
+	assert.Nil(t, err)
+	assert.True(t, status.Ready())
+	assert.Equal(t, remote.PublicAccount, status.Linked)
+
+	stored, err := manager.RemoteAccount(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, remote.PublicAccount, stored.PublicAccount)
+
+	_, err = manager.Link(ctx, testLinkDeadline)
+	assert.Equal(t, ErrAccountAlreadyLinked, errors.Cause(err))
+
+	rotated, err := manager.Rotate(ctx, testLinkDeadline)
+	assert.Nil(t, err)
+	assert.NotEqual(t, remote.PublicAccount, rotated.PublicAccount)
+	_, err = store.LoadRemoteKey(remote.PublicAccount.PublicKey)
+	assert.Equal(t, ErrRemoteKeyNotFound, err)
+
+	assert.Nil(t, manager.Unlink(ctx, testLinkDeadline))
+	// rotation is announced as separate unlink and link
+	assert.Equal(t, 4, source.announced)
+	assert.Empty(t, store.keys)
+	assert.Equal(t, ErrAccountNotLinked, manager.Unlink(ctx, testLinkDeadline))
+}
+
+func TestHarvestingManager_LinkNotConfirmed(t *testing.T) {
+	main, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	source := &fakeHarvestingSource{drop: true}
+	store := NewMemoryRemoteKeyStore()
+	manager := newHarvestingManager(main, source, store)
+	manager.PollInterval = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = manager.Link(ctx, testLinkDeadline)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	// key is stored before announcing, so it isn't lost when link is confirmed later
+	assert.Len(t, store.keys, 1)
+
+	source.info = &AccountInfo{AccountType: MainAccount, LinkedAccount: main.PublicAccount}
+	status, err := manager.Status(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, status.Ready())
+}
+
+func TestHarvestingManager_LinkFailed(t *testing.T) {
+	main, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	source := &fakeHarvestingSource{announceErr: errors.New("node is down")}
+	store := NewMemoryRemoteKeyStore()
+	manager := newHarvestingManager(main, source, store)
+	manager.PollInterval = time.Millisecond
+
+	_, err = manager.Link(context.Background(), testLinkDeadline)
+	assert.Equal(t, source.announceErr, err)
+	assert.Empty(t, store.keys)
+
+	// node rejects link
+	source.announceErr = nil
+	source.drop = true
+	source.status = &TransactionStatus{Group: "failed", Status: "Failure_Core_Insufficient_Balance"}
+	_, err = manager.Link(context.Background(), testLinkDeadline)
+	assert.Equal(t, ErrLinkTransactionFailed, errors.Cause(err))
+	assert.Empty(t, store.keys)
+
+	// link isn't confirmed before its deadline
+	source.status = &TransactionStatus{Group: "unconfirmed", Status: "Success"}
+	_, err = manager.Link(context.Background(), NewDeadline(-time.Second))
+	assert.Equal(t, ErrLinkTransactionExpired, err)
+	assert.Empty(t, store.keys)
+}
+
+func TestHarvestingManager_RotateFailed(t *testing.T) {
+	main, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	source := &fakeHarvestingSource{}
+	store := NewMemoryRemoteKeyStore()
+	manager := newHarvestingManager(main, source, store)
+	manager.PollInterval = time.Millisecond
+
+	remote, err := manager.Link(context.Background(), testLinkDeadline)
+	assert.Nil(t, err)
+
+	// node rejects unlink, so new remote account is never linked
+	source.drop = true
+	source.status = &TransactionStatus{Group: "failed", Status: "Failure_Core_Insufficient_Balance"}
+	_, err = manager.Rotate(context.Background(), testLinkDeadline)
+	assert.Equal(t, ErrLinkTransactionFailed, errors.Cause(err))
+	assert.Len(t, store.keys, 1)
+	assert.NotNil(t, store.keys[remote.PublicAccount.PublicKey])
+
+	// node rejects link after unlink is confirmed
+	source.drop = false
+	source.dropLinks = true
+	_, err = manager.Rotate(context.Background(), testLinkDeadline)
+	assert.Equal(t, ErrLinkTransactionFailed, errors.Cause(err))
+	assert.Empty(t, store.keys)
+
+	status, err := manager.Status(context.Background())
+	assert.Nil(t, err)
+	assert.Nil(t, status.Linked)
+}
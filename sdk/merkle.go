@@ -0,0 +1,76 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"golang.org/x/crypto/sha3"
+)
+
+// returns merkle root rebuilt from leaf and path. Empty path means that leaf is the only one in tree
+func MerkleRoot(leaf *Hash, path []*MerklePathItem) (*Hash, error) {
+	if leaf == nil {
+		return nil, ErrNilHash
+	}
+
+	root := *leaf
+	for _, item := range path {
+		if item == nil || item.Hash == nil {
+			return nil, ErrNilHash
+		}
+
+		h := sha3.New256()
+		switch item.Position {
+		case MerkleLeft:
+			h.Write(item.Hash[:])
+			h.Write(root[:])
+		case MerkleRight:
+			h.Write(root[:])
+			h.Write(item.Hash[:])
+		default:
+			return nil, ErrUnknownMerklePosition
+		}
+
+		copy(root[:], h.Sum(nil))
+	}
+
+	return &root, nil
+}
+
+// checks that path leads from leaf to expected root. Returns ErrMerkleRootMismatch when it doesn't
+func VerifyMerklePath(leaf *Hash, path []*MerklePathItem, root *Hash) error {
+	if root == nil {
+		return ErrNilHash
+	}
+
+	rebuilt, err := MerkleRoot(leaf, path)
+	if err != nil {
+		return err
+	}
+
+	if !rebuilt.Equal(root) {
+		return ErrMerkleRootMismatch
+	}
+
+	return nil
+}
+
+// checks offline that transaction is included in block, using MerkleComponentHash of transaction
+// and path from BlockchainService.GetTransactionMerklePath
+func VerifyTransactionMerklePath(info *TransactionInfo, path []*MerklePathItem, block *BlockInfo) error {
+	if info == nil || block == nil {
+		return ErrNilHash
+	}
+
+	return VerifyMerklePath(info.MerkleComponentHash, path, block.BlockTransactionsHash)
+}
+
+// checks offline that statement with passed hash is included in block, using path from ReceiptService.GetReceiptMerklePath
+func VerifyReceiptMerklePath(statementHash *Hash, path []*MerklePathItem, block *BlockInfo) error {
+	if block == nil {
+		return ErrNilHash
+	}
+
+	return VerifyMerklePath(statementHash, path, block.BlockReceiptsHash)
+}
@@ -0,0 +1,326 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// MultisigSetup is desired final state of multisig account.
+// Empty Cosignatories with zero thresholds converts multisig account back to a normal one
+type MultisigSetup struct {
+	Cosignatories []*PublicAccount
+	MinApproval   int32
+	MinRemoval    int32
+}
+
+// MultisigChange contains transactions which turn current state of account into MultisigSetup
+type MultisigChange struct {
+	Account *PublicAccount
+	// Nil when account isn't multisig yet
+	Current *MultisigAccountInfo
+	Setup   *MultisigSetup
+	Added   []*PublicAccount
+	Removed []*PublicAccount
+	// Transactions of Account which should be applied in order inside of one bonded aggregate.
+	// The node allows to remove one cosignatory per transaction, so every removal goes into its own transaction
+	// and thresholds are chosen so that every intermediate state is valid
+	Transactions []*ModifyMultisigAccountTransaction
+	// New cosignatories which should cosign aggregate to opt in
+	OptIn []*PublicAccount
+}
+
+// returns change of multisig account from its current state to passed setup.
+// Current state and graph of account are nil when account isn't multisig or cosignatory yet.
+// Setups which would lock account forever, e.g. with threshold above number of cosignatories or with cycles, are rejected
+func NewMultisigChange(deadline *Deadline, account *PublicAccount, current *MultisigAccountInfo, graph *MultisigAccountGraphInfo, setup *MultisigSetup, networkType NetworkType) (*MultisigChange, error) {
+	if account == nil {
+		return nil, ErrNilAccount
+	}
+
+	if err := validateMultisigSetup(account, graph, setup); err != nil {
+		return nil, err
+	}
+
+	var (
+		currentMinApproval, currentMinRemoval int32
+		currentCosignatories                  []*PublicAccount
+	)
+	if current != nil {
+		currentMinApproval, currentMinRemoval = current.MinApproval, current.MinRemoval
+		currentCosignatories = current.Cosignatories
+	}
+
+	change := &MultisigChange{
+		Account: account,
+		Current: current,
+		Setup:   setup,
+		Added:   cosignatoriesDifference(setup.Cosignatories, currentCosignatories),
+		Removed: cosignatoriesDifference(currentCosignatories, setup.Cosignatories),
+	}
+	change.OptIn = change.Added
+
+	if len(change.Added) == 0 && len(change.Removed) == 0 &&
+		currentMinApproval == setup.MinApproval && currentMinRemoval == setup.MinRemoval {
+		return nil, ErrNoChanges
+	}
+
+	steps := len(change.Removed)
+	if steps == 0 {
+		steps = 1
+	}
+
+	// thresholds are set by the first transaction, because number of cosignatories only decreases after it.
+	// Thresholds can be zero only without cosignatories, so they are set to zero by the last transaction
+	approval, removal := currentMinApproval, currentMinRemoval
+	for i := 0; i < steps; i++ {
+		nextApproval, nextRemoval := setup.MinApproval, setup.MinRemoval
+		if i < steps-1 {
+			nextApproval, nextRemoval = maxInt32(nextApproval, 1), maxInt32(nextRemoval, 1)
+		}
+
+		approvalDelta, err := multisigDelta(nextApproval - approval)
+		if err != nil {
+			return nil, err
+		}
+
+		removalDelta, err := multisigDelta(nextRemoval - removal)
+		if err != nil {
+			return nil, err
+		}
+		approval, removal = nextApproval, nextRemoval
+
+		modifications := make([]*MultisigCosignatoryModification, 0)
+		if i == 0 {
+			for _, a := range change.Added {
+				modifications = append(modifications, &MultisigCosignatoryModification{Add, a})
+			}
+		}
+
+		if i < len(change.Removed) {
+			modifications = append(modifications, &MultisigCosignatoryModification{Remove, change.Removed[i]})
+		}
+
+		tx, err := NewModifyMultisigAccountTransaction(deadline, approvalDelta, removalDelta, modifications, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		change.Transactions = append(change.Transactions, tx)
+	}
+
+	return change, nil
+}
+
+// returns change of multisig account from its current state requested from REST to passed setup
+func (c *Client) NewMultisigChange(ctx context.Context, deadline *Deadline, account *PublicAccount, setup *MultisigSetup) (*MultisigChange, error) {
+	if account == nil {
+		return nil, ErrNilAccount
+	}
+
+	current, err := c.Account.GetMultisigAccountInfo(ctx, account.Address)
+	if err != nil && err != ErrResourceNotFound {
+		return nil, err
+	}
+
+	if current != nil && len(current.Cosignatories) == 0 {
+		// account is only a cosignatory of other accounts
+		current = nil
+	}
+
+	graph, err := c.Account.GetMultisigAccountGraphInfo(ctx, account.Address)
+	if err != nil && err != ErrResourceNotFound {
+		return nil, err
+	}
+
+	change, err := NewMultisigChange(deadline, account, current, graph, setup, c.config.NetworkType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range change.Transactions {
+		if err = c.modifyTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
+
+	return change, nil
+}
+
+// SignedMultisigChange contains signed bonded aggregate and hash lock for it.
+// Lock should be announced and confirmed before aggregate is announced with AnnounceAggregateBonded
+type SignedMultisigChange struct {
+	Aggregate *SignedTransaction
+	Lock      *SignedTransaction
+}
+
+// SignMultisigChange builds bonded aggregate of change and hash lock of currency amount required by network config.
+// Both are signed by initiator, which is the account itself when it isn't multisig yet or one of its cosignatories otherwise
+func (c *Client) SignMultisigChange(ctx context.Context, deadline *Deadline, lockDuration Duration, initiator *Account, change *MultisigChange) (*SignedMultisigChange, error) {
+	if initiator == nil {
+		return nil, ErrNilAccount
+	}
+
+	inner := make([]Transaction, len(change.Transactions))
+	for i, tx := range change.Transactions {
+		tx.ToAggregate(change.Account)
+		inner[i] = tx
+	}
+
+	aggregate, err := c.NewBondedAggregateTransaction(deadline, inner)
+	if err != nil {
+		return nil, err
+	}
+
+	signedAggregate, err := initiator.Sign(aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := c.Network.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mosaicId, err := config.NetworkConfig.CurrencyMosaicId()
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := config.NetworkConfig.LockedFundsPerAggregate()
+	if err != nil {
+		return nil, err
+	}
+
+	mosaic, err := NewMosaic(mosaicId, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.NewLockFundsTransaction(deadline, mosaic, lockDuration, signedAggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	signedLock, err := initiator.Sign(lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedMultisigChange{
+		Aggregate: signedAggregate,
+		Lock:      signedLock,
+	}, nil
+}
+
+func validateMultisigSetup(account *PublicAccount, graph *MultisigAccountGraphInfo, setup *MultisigSetup) error {
+	if setup == nil {
+		return ErrNilMultisigSetup
+	}
+
+	count := int32(len(setup.Cosignatories))
+	switch {
+	case setup.MinApproval > count || setup.MinRemoval > count:
+		return ErrMultisigThresholdTooHigh
+	case count > 0 && (setup.MinApproval < 1 || setup.MinRemoval < 1):
+		return ErrMultisigThresholdTooLow
+	}
+
+	// account can't be cosignatory of itself directly or through accounts cosigned by it
+	forbidden := multisigAncestors(account, graph)
+	forbidden[account.PublicKey] = true
+
+	seen := make(map[string]bool, len(setup.Cosignatories))
+	for _, c := range setup.Cosignatories {
+		if c == nil {
+			return ErrNilAccount
+		}
+
+		if seen[c.PublicKey] {
+			return errors.Wrapf(ErrDuplicateCosignatory, "cosignatory %s", c.PublicKey)
+		}
+		seen[c.PublicKey] = true
+
+		if forbidden[c.PublicKey] {
+			return errors.Wrapf(ErrMultisigCycle, "cosignatory %s", c.PublicKey)
+		}
+	}
+
+	return nil
+}
+
+// returns public keys of multisig accounts which are cosigned by account directly or through other accounts
+func multisigAncestors(account *PublicAccount, graph *MultisigAccountGraphInfo) map[string]bool {
+	ancestors := make(map[string]bool)
+	if graph == nil {
+		return ancestors
+	}
+
+	entries := make(map[string]*MultisigAccountInfo)
+	for _, level := range graph.MultisigAccounts {
+		for _, info := range level {
+			entries[info.Account.PublicKey] = info
+		}
+	}
+
+	queue := []string{account.PublicKey}
+	for len(queue) > 0 {
+		entry, ok := entries[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+
+		for _, m := range entry.MultisigAccounts {
+			if !ancestors[m.PublicKey] {
+				ancestors[m.PublicKey] = true
+				queue = append(queue, m.PublicKey)
+			}
+		}
+	}
+
+	return ancestors
+}
+
+// returns accounts of left which aren't in right ordered by public key
+func cosignatoriesDifference(left, right []*PublicAccount) []*PublicAccount {
+	inRight := make(map[string]bool, len(right))
+	for _, a := range right {
+		inRight[a.PublicKey] = true
+	}
+
+	difference := make([]*PublicAccount, 0)
+	for _, a := range left {
+		if !inRight[a.PublicKey] {
+			difference = append(difference, a)
+		}
+	}
+
+	sort.Slice(difference, func(i, j int) bool {
+		return difference[i].PublicKey < difference[j].PublicKey
+	})
+
+	return difference
+}
+
+func multisigDelta(delta int32) (int8, error) {
+	if delta < math.MinInt8 || delta > math.MaxInt8 {
+		return 0, ErrMultisigDeltaOverflow
+	}
+
+	return int8(delta), nil
+}
+
+func maxInt32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+
+	return b
+}
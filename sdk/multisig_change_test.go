@@ -0,0 +1,132 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMultisigChange_Conversion(t *testing.T) {
+	tr := newTestTreasury(t)
+	account := testMultisigOutsider(t).PublicAccount
+
+	setup := &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.a.PublicAccount, tr.b.PublicAccount, tr.c.PublicAccount},
+		MinApproval:   2,
+		MinRemoval:    2,
+	}
+
+	change, err := NewMultisigChange(fakeDeadline, account, nil, nil, setup, MijinTest)
+	assert.Nil(t, err)
+	assert.Len(t, change.Added, 3)
+	assert.Empty(t, change.Removed)
+	assert.Equal(t, change.Added, change.OptIn)
+	assert.Len(t, change.Transactions, 1)
+	assert.Equal(t, int8(2), change.Transactions[0].MinApprovalDelta)
+	assert.Equal(t, int8(2), change.Transactions[0].MinRemovalDelta)
+	assert.Len(t, change.Transactions[0].Modifications, 3)
+}
+
+func TestNewMultisigChange_Modification(t *testing.T) {
+	tr := newTestTreasury(t)
+	current := tr.graph.MultisigAccounts[0][0]
+
+	// 2 of 3 by a, b and board becomes 1 of 2 by a and d, so board and b are removed one by one
+	setup := &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.a.PublicAccount, tr.d.PublicAccount},
+		MinApproval:   1,
+		MinRemoval:    1,
+	}
+
+	change, err := NewMultisigChange(fakeDeadline, tr.treasury, current, tr.graph, setup, MijinTest)
+	assert.Nil(t, err)
+	assert.Equal(t, []*PublicAccount{tr.d.PublicAccount}, change.OptIn)
+	assert.ElementsMatch(t, []*PublicAccount{tr.b.PublicAccount, tr.board}, change.Removed)
+	assert.Len(t, change.Transactions, 2)
+
+	first, second := change.Transactions[0], change.Transactions[1]
+	assert.Equal(t, int8(-1), first.MinApprovalDelta)
+	assert.Equal(t, int8(-2), first.MinRemovalDelta)
+	assert.Equal(t, []*MultisigCosignatoryModification{{Add, tr.d.PublicAccount}, {Remove, change.Removed[0]}}, first.Modifications)
+	assert.Equal(t, int8(0), second.MinApprovalDelta)
+	assert.Equal(t, int8(0), second.MinRemovalDelta)
+	assert.Equal(t, []*MultisigCosignatoryModification{{Remove, change.Removed[1]}}, second.Modifications)
+
+	// thresholds stay positive until the last cosignatory is removed
+	change, err = NewMultisigChange(fakeDeadline, tr.treasury, current, tr.graph, &MultisigSetup{}, MijinTest)
+	assert.Nil(t, err)
+	assert.Len(t, change.Transactions, 3)
+	assert.Equal(t, int8(-1), change.Transactions[0].MinApprovalDelta)
+	assert.Equal(t, int8(-2), change.Transactions[0].MinRemovalDelta)
+	assert.Equal(t, int8(-1), change.Transactions[2].MinApprovalDelta)
+	assert.Equal(t, int8(-1), change.Transactions[2].MinRemovalDelta)
+}
+
+func TestNewMultisigChange_Rejected(t *testing.T) {
+	tr := newTestTreasury(t)
+	current := tr.graph.MultisigAccounts[0][0]
+	cosignatories := current.Cosignatories
+
+	newChange := func(account *PublicAccount, setup *MultisigSetup) error {
+		_, err := NewMultisigChange(fakeDeadline, account, current, tr.graph, setup, MijinTest)
+		return errors.Cause(err)
+	}
+
+	assert.Equal(t, ErrMultisigThresholdTooHigh, newChange(tr.treasury, &MultisigSetup{Cosignatories: cosignatories, MinApproval: 4, MinRemoval: 1}))
+	assert.Equal(t, ErrMultisigThresholdTooHigh, newChange(tr.treasury, &MultisigSetup{MinApproval: 1}))
+	assert.Equal(t, ErrMultisigThresholdTooLow, newChange(tr.treasury, &MultisigSetup{Cosignatories: cosignatories, MinApproval: 1}))
+	assert.Equal(t, ErrDuplicateCosignatory, newChange(tr.treasury, &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.a.PublicAccount, tr.a.PublicAccount}, MinApproval: 1, MinRemoval: 1,
+	}))
+	assert.Equal(t, ErrMultisigCycle, newChange(tr.treasury, &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.treasury}, MinApproval: 1, MinRemoval: 1,
+	}))
+	// board is cosigned by c, so treasury can't become cosignatory of c
+	assert.Equal(t, ErrMultisigCycle, newChange(tr.c.PublicAccount, &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.treasury}, MinApproval: 1, MinRemoval: 1,
+	}))
+	assert.Equal(t, ErrNoChanges, newChange(tr.treasury, &MultisigSetup{
+		Cosignatories: cosignatories, MinApproval: current.MinApproval, MinRemoval: current.MinRemoval,
+	}))
+	assert.Equal(t, ErrNilMultisigSetup, newChange(tr.treasury, nil))
+}
+
+func TestClient_SignMultisigChange(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height":[300,0]}`,
+	})
+	defer mockServ.Close()
+
+	mockServ.AddRouter(&mock.Router{
+		Path: fmt.Sprintf(configRoute, Height(300)),
+		RespBody: fmt.Sprintf(`{"networkConfig": {"height": [1, 0], "networkConfig": %s, "supportedEntityVersions": "{\"entities\": []}"}}`,
+			strconv.Quote(testNetworkConfigText)),
+	})
+
+	conf, err := NewConfigWithReputation([]string{mockServ.GetServerURL()}, MijinTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, &Hash{1}, DefaultFeeCalculationStrategy)
+	assert.Nil(t, err)
+	client := NewClient(nil, conf)
+
+	initiator := testMultisigOutsider(t)
+	tr := newTestTreasury(t)
+
+	change, err := NewMultisigChange(fakeDeadline, initiator.PublicAccount, nil, nil, &MultisigSetup{
+		Cosignatories: []*PublicAccount{tr.a.PublicAccount}, MinApproval: 1, MinRemoval: 1,
+	}, MijinTest)
+	assert.Nil(t, err)
+
+	signed, err := client.SignMultisigChange(ctx, fakeDeadline, 480, initiator, change)
+	assert.Nil(t, err)
+	assert.Equal(t, AggregateBonded, signed.Aggregate.EntityType)
+	assert.Equal(t, Lock, signed.Lock.EntityType)
+	assert.Equal(t, initiator.PublicAccount, change.Transactions[0].Signer)
+}
@@ -0,0 +1,459 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+)
+
+// MultisigSigningPlanner works out which cosignatories should sign transaction of multisig account.
+// Multisig account should be approved by MinApproval of its cosignatories or by MinRemoval when transaction
+// removes cosignatories, cosignatories which are multisig themselves always require MinApproval, like the node checks it
+type MultisigSigningPlanner struct {
+	Multisig *PublicAccount
+	entries  map[string]*MultisigAccountInfo
+	accounts map[string]*PublicAccount
+}
+
+// returns planner for multisig account from its graph, e.g. from AccountService.GetMultisigAccountGraphInfo
+func NewMultisigSigningPlanner(multisig *PublicAccount, graph *MultisigAccountGraphInfo) (*MultisigSigningPlanner, error) {
+	if multisig == nil {
+		return nil, ErrNilAccount
+	}
+
+	p := &MultisigSigningPlanner{
+		Multisig: multisig,
+		entries:  make(map[string]*MultisigAccountInfo),
+		accounts: make(map[string]*PublicAccount),
+	}
+
+	if graph != nil {
+		p.indexCosignatories(graph)
+	}
+
+	if !p.isMultisig(multisig.PublicKey) {
+		return nil, ErrNotMultisigAccount
+	}
+
+	return p, nil
+}
+
+// graph also contains multisig accounts which multisig account cosigns, so only accounts reachable
+// through cosignatories of multisig account are indexed
+func (p *MultisigSigningPlanner) indexCosignatories(graph *MultisigAccountGraphInfo) {
+	infos := make(map[string]*MultisigAccountInfo)
+	for _, level := range graph.MultisigAccounts {
+		for _, info := range level {
+			infos[info.Account.PublicKey] = info
+		}
+	}
+
+	queue := []string{p.Multisig.PublicKey}
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		info, ok := infos[key]
+		if !ok {
+			continue
+		}
+
+		if _, ok := p.entries[key]; ok {
+			continue
+		}
+
+		account := info.Account
+		p.entries[key] = info
+		p.accounts[key] = &account
+
+		for _, c := range info.Cosignatories {
+			p.accounts[c.PublicKey] = c
+			queue = append(queue, c.PublicKey)
+		}
+	}
+}
+
+// returns planner for multisig account built from its graph
+func (c *Client) NewMultisigSigningPlanner(ctx context.Context, multisig *PublicAccount) (*MultisigSigningPlanner, error) {
+	if multisig == nil {
+		return nil, ErrNilAccount
+	}
+
+	graph, err := c.Account.GetMultisigAccountGraphInfo(ctx, multisig.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMultisigSigningPlanner(multisig, graph)
+}
+
+// returns true when transaction removes cosignatories of multisig account, so MinRemoval is required
+func IsMultisigRemoval(tx Transaction) bool {
+	if tx, ok := tx.(*ModifyMultisigAccountTransaction); ok {
+		for _, m := range tx.Modifications {
+			if m.Type == Remove {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// returns every minimal set of signers which is sufficient for passed transaction, smaller sets first.
+// Signers are accounts which aren't multisig themselves
+func (p *MultisigSigningPlanner) SignerSets(tx Transaction) [][]*PublicAccount {
+	keySets := p.signerSets(p.Multisig.PublicKey, IsMultisigRemoval(tx), make(map[string]bool))
+
+	sets := make([][]*PublicAccount, len(keySets))
+	for i, keys := range keySets {
+		sets[i] = make([]*PublicAccount, len(keys))
+		for j, key := range keys {
+			sets[i][j] = p.accounts[key]
+		}
+	}
+
+	return sets
+}
+
+// returns true when signatures of passed signers are sufficient for transaction of multisig account
+func (p *MultisigSigningPlanner) IsSufficient(tx Transaction, signers ...*PublicAccount) bool {
+	signed := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		if s != nil {
+			signed[s.PublicKey] = true
+		}
+	}
+
+	return p.isApproved(p.Multisig.PublicKey, IsMultisigRemoval(tx), signed, make(map[string]bool))
+}
+
+// returns minimal sets of signers which are still missing after passed signers signed, smaller sets first.
+// It returns nil when passed signers are already sufficient and empty slice when threshold can't be reached
+func (p *MultisigSigningPlanner) MissingSigners(tx Transaction, signers ...*PublicAccount) [][]*PublicAccount {
+	signed := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		if s != nil {
+			signed[s.PublicKey] = true
+		}
+	}
+
+	return p.missingSigners(IsMultisigRemoval(tx), signed)
+}
+
+// returns every account of the graph which can sign, i.e. which isn't multisig itself, ordered by public key
+func (p *MultisigSigningPlanner) Signers() []*PublicAccount {
+	keys := make([]string, 0, len(p.accounts))
+	for key := range p.accounts {
+		if !p.isMultisig(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	signers := make([]*PublicAccount, len(keys))
+	for i, key := range keys {
+		signers[i] = p.accounts[key]
+	}
+
+	return signers
+}
+
+func (p *MultisigSigningPlanner) missingSigners(removal bool, signed map[string]bool) [][]*PublicAccount {
+	if p.isApproved(p.Multisig.PublicKey, removal, signed, make(map[string]bool)) {
+		return nil
+	}
+
+	missingKeySets := make([][]string, 0)
+	for _, keys := range p.signerSets(p.Multisig.PublicKey, removal, make(map[string]bool)) {
+		missing := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if !signed[key] {
+				missing = append(missing, key)
+			}
+		}
+
+		missingKeySets = append(missingKeySets, missing)
+	}
+
+	missingKeySets = minimalKeySets(missingKeySets)
+
+	missingSets := make([][]*PublicAccount, len(missingKeySets))
+	for i, keys := range missingKeySets {
+		missingSets[i] = make([]*PublicAccount, len(keys))
+		for j, key := range keys {
+			missingSets[i][j] = p.accounts[key]
+		}
+	}
+
+	return missingSets
+}
+
+// Plan returns plan of signing passed transaction by available accounts.
+// When available accounts are sufficient the plan contains the smallest sufficient set of them,
+// otherwise it contains every available signer of the multisig graph and transaction should be bonded
+func (p *MultisigSigningPlanner) Plan(tx Transaction, available ...*Account) (*MultisigSigningPlan, error) {
+	byKey := make(map[string]*Account, len(available))
+	for _, a := range available {
+		if a != nil && a.PublicAccount != nil {
+			byKey[a.PublicAccount.PublicKey] = a
+		}
+	}
+
+	plan := &MultisigSigningPlan{
+		Multisig:    p.Multisig,
+		Transaction: tx,
+	}
+
+	for _, set := range p.SignerSets(tx) {
+		sufficient := true
+		for _, signer := range set {
+			if _, ok := byKey[signer.PublicKey]; !ok {
+				sufficient = false
+				break
+			}
+		}
+
+		if sufficient {
+			plan.Sufficient = true
+			plan.Signers = make([]*Account, len(set))
+			for i, signer := range set {
+				plan.Signers[i] = byKey[signer.PublicKey]
+			}
+
+			return plan, nil
+		}
+	}
+
+	signed := make(map[string]bool, len(byKey))
+	for key := range byKey {
+		signed[key] = true
+	}
+	plan.Missing = p.missingSigners(IsMultisigRemoval(tx), signed)
+
+	keys := make([]string, 0, len(byKey))
+	for key := range byKey {
+		if _, ok := p.accounts[key]; ok && !p.isMultisig(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		plan.Signers = append(plan.Signers, byKey[key])
+	}
+
+	if len(plan.Signers) == 0 {
+		return nil, ErrNoEligibleSigners
+	}
+
+	return plan, nil
+}
+
+func (p *MultisigSigningPlanner) isMultisig(key string) bool {
+	entry, ok := p.entries[key]
+	return ok && len(entry.Cosignatories) > 0
+}
+
+func (p *MultisigSigningPlanner) minCosignatories(entry *MultisigAccountInfo, removal bool) int {
+	min := entry.MinApproval
+	if removal {
+		min = entry.MinRemoval
+	}
+
+	if min < 1 {
+		return 1
+	}
+
+	return int(min)
+}
+
+// visiting guards against cycles, which the node doesn't allow
+func (p *MultisigSigningPlanner) isApproved(key string, removal bool, signed, visiting map[string]bool) bool {
+	if !p.isMultisig(key) {
+		return signed[key]
+	}
+
+	if visiting[key] {
+		return false
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	entry := p.entries[key]
+	approved := 0
+	for _, c := range entry.Cosignatories {
+		// the node checks MinRemoval only of the account which is modified
+		if p.isApproved(c.PublicKey, false, signed, visiting) {
+			approved++
+		}
+	}
+
+	return approved >= p.minCosignatories(entry, removal)
+}
+
+// returns minimal sets of sorted public keys of signers approving account with passed key
+func (p *MultisigSigningPlanner) signerSets(key string, removal bool, visiting map[string]bool) [][]string {
+	if !p.isMultisig(key) {
+		return [][]string{{key}}
+	}
+
+	if visiting[key] {
+		return nil
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	entry := p.entries[key]
+	cosignatorySets := make([][][]string, len(entry.Cosignatories))
+	for i, c := range entry.Cosignatories {
+		cosignatorySets[i] = p.signerSets(c.PublicKey, false, visiting)
+	}
+
+	sets := make([][]string, 0)
+	forEachCombination(len(cosignatorySets), p.minCosignatories(entry, removal), func(indexes []int) {
+		combined := [][]string{nil}
+		for _, i := range indexes {
+			next := make([][]string, 0, len(combined)*len(cosignatorySets[i]))
+			for _, base := range combined {
+				for _, set := range cosignatorySets[i] {
+					next = append(next, unionOfKeys(base, set))
+				}
+			}
+			combined = next
+		}
+
+		sets = append(sets, combined...)
+	})
+
+	return minimalKeySets(sets)
+}
+
+// calls f with every combination of k indexes from [0, n)
+func forEachCombination(n, k int, f func(indexes []int)) {
+	if k > n {
+		return
+	}
+
+	indexes := make([]int, k)
+	var visit func(start, depth int)
+	visit = func(start, depth int) {
+		if depth == k {
+			f(indexes)
+			return
+		}
+
+		for i := start; i <= n-k+depth; i++ {
+			indexes[depth] = i
+			visit(i+1, depth+1)
+		}
+	}
+
+	visit(0, 0)
+}
+
+// returns sorted union of sorted keys
+func unionOfKeys(a, b []string) []string {
+	union := make([]string, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || (i < len(a) && a[i] < b[j]):
+			union = append(union, a[i])
+			i++
+		case i == len(a) || b[j] < a[i]:
+			union = append(union, b[j])
+			j++
+		default:
+			union = append(union, a[i])
+			i++
+			j++
+		}
+	}
+
+	return union
+}
+
+// removes duplicates and supersets of other sets, smaller sets go first
+func minimalKeySets(sets [][]string) [][]string {
+	sort.SliceStable(sets, func(i, j int) bool {
+		return len(sets[i]) < len(sets[j])
+	})
+
+	minimal := make([][]string, 0, len(sets))
+	for _, set := range sets {
+		redundant := false
+		for _, m := range minimal {
+			if len(unionOfKeys(m, set)) == len(set) {
+				redundant = true
+				break
+			}
+		}
+
+		if !redundant {
+			minimal = append(minimal, set)
+		}
+	}
+
+	return minimal
+}
+
+// MultisigSigningPlan describes signing of transaction of multisig account by available accounts
+type MultisigSigningPlan struct {
+	Multisig    *PublicAccount
+	Transaction Transaction
+	// True when Signers are sufficient and complete aggregate can be announced
+	Sufficient bool
+	// Available accounts which should sign. The first one signs aggregate, others cosign it
+	Signers []*Account
+	// Minimal sets of signers which are still missing, smaller sets first. Empty when plan is sufficient
+	Missing [][]*PublicAccount
+}
+
+// Sign signs aggregate with the first signer of plan and cosigns it with other ones
+func (p *MultisigSigningPlan) Sign(aggregate *AggregateTransaction) (*SignedTransaction, error) {
+	if len(p.Signers) == 0 {
+		return nil, ErrNoEligibleSigners
+	}
+
+	return p.Signers[0].SignWithCosignatures(aggregate, p.Signers[1:])
+}
+
+// Cosign returns cosignatures of every signer of plan for aggregate announced by somebody else
+func (p *MultisigSigningPlan) Cosign(aggregate *AggregateTransaction) ([]*CosignatureSignedTransaction, error) {
+	cosignatures := make([]*CosignatureSignedTransaction, 0, len(p.Signers))
+	for _, signer := range p.Signers {
+		if aggregate.Signer != nil && aggregate.Signer.PublicKey == signer.PublicAccount.PublicKey {
+			continue
+		}
+
+		tx, err := NewCosignatureTransaction(aggregate)
+		if err != nil {
+			return nil, err
+		}
+
+		signed, err := signer.SignCosignatureTransaction(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		cosignatures = append(cosignatures, signed)
+	}
+
+	return cosignatures, nil
+}
+
+// returns aggregate with transaction of multisig account as inner one.
+// It is complete when signers of plan are sufficient and bonded otherwise
+func (c *Client) NewMultisigAggregateTransaction(deadline *Deadline, plan *MultisigSigningPlan) (*AggregateTransaction, error) {
+	plan.Transaction.GetAbstractTransaction().ToAggregate(plan.Multisig)
+
+	if plan.Sufficient {
+		return c.NewCompleteAggregateTransaction(deadline, []Transaction{plan.Transaction})
+	}
+
+	return c.NewBondedAggregateTransaction(deadline, []Transaction{plan.Transaction})
+}
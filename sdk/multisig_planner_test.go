@@ -0,0 +1,172 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTreasury struct {
+	treasury, board *PublicAccount
+	a, b, c, d      *Account
+	graph           *MultisigAccountGraphInfo
+}
+
+// treasury is 2 of 3 by a, b and board for approval and 3 of 3 for removal, board is 1 of 2 by c and d
+func newTestTreasury(t *testing.T) *testTreasury {
+	newAccount := func() *Account {
+		a, err := NewAccount(MijinTest, GenerationHash)
+		assert.Nil(t, err)
+		return a
+	}
+
+	tr := &testTreasury{
+		treasury: newAccount().PublicAccount,
+		board:    newAccount().PublicAccount,
+		a:        newAccount(),
+		b:        newAccount(),
+		c:        newAccount(),
+		d:        newAccount(),
+	}
+
+	tr.graph = &MultisigAccountGraphInfo{MultisigAccounts: map[int32][]*MultisigAccountInfo{
+		0: {{
+			Account:       *tr.treasury,
+			MinApproval:   2,
+			MinRemoval:    3,
+			Cosignatories: []*PublicAccount{tr.a.PublicAccount, tr.b.PublicAccount, tr.board},
+		}},
+		1: {
+			{Account: *tr.a.PublicAccount, MultisigAccounts: []*PublicAccount{tr.treasury}},
+			{Account: *tr.b.PublicAccount, MultisigAccounts: []*PublicAccount{tr.treasury}},
+			{
+				Account:          *tr.board,
+				MinApproval:      1,
+				MinRemoval:       2,
+				Cosignatories:    []*PublicAccount{tr.c.PublicAccount, tr.d.PublicAccount},
+				MultisigAccounts: []*PublicAccount{tr.treasury},
+			},
+		},
+		2: {
+			{Account: *tr.c.PublicAccount, MultisigAccounts: []*PublicAccount{tr.board}},
+			{Account: *tr.d.PublicAccount, MultisigAccounts: []*PublicAccount{tr.board}},
+		},
+	}}
+
+	return tr
+}
+
+func TestMultisigSigningPlanner_SignerSets(t *testing.T) {
+	tr := newTestTreasury(t)
+
+	planner, err := NewMultisigSigningPlanner(tr.treasury, tr.graph)
+	assert.Nil(t, err)
+
+	transfer := &TransferTransaction{}
+	sets := planner.SignerSets(transfer)
+	assert.Len(t, sets, 5)
+	assert.ElementsMatch(t, []*PublicAccount{tr.a.PublicAccount, tr.b.PublicAccount}, sets[0])
+	for _, set := range sets {
+		assert.Len(t, set, 2)
+		assert.True(t, planner.IsSufficient(transfer, set...))
+	}
+
+	assert.True(t, planner.IsSufficient(transfer, tr.a.PublicAccount, tr.d.PublicAccount))
+	assert.False(t, planner.IsSufficient(transfer, tr.c.PublicAccount, tr.d.PublicAccount))
+	assert.False(t, planner.IsSufficient(transfer, tr.a.PublicAccount, tr.board))
+
+	removal := &ModifyMultisigAccountTransaction{Modifications: []*MultisigCosignatoryModification{{Remove, tr.b.PublicAccount}}}
+	sets = planner.SignerSets(removal)
+	// board approves removal from treasury by MinApproval, its MinRemoval is only for its own cosignatories
+	assert.Len(t, sets, 2)
+	for _, set := range sets {
+		assert.Len(t, set, 3)
+		assert.Subset(t, set, []*PublicAccount{tr.a.PublicAccount, tr.b.PublicAccount})
+	}
+	assert.True(t, planner.IsSufficient(removal, tr.a.PublicAccount, tr.b.PublicAccount, tr.c.PublicAccount))
+	assert.False(t, planner.IsSufficient(removal, tr.a.PublicAccount, tr.c.PublicAccount, tr.d.PublicAccount))
+
+	_, err = NewMultisigSigningPlanner(tr.a.PublicAccount, tr.graph)
+	assert.Equal(t, ErrNotMultisigAccount, err)
+}
+
+func TestMultisigSigningPlanner_Plan(t *testing.T) {
+	tr := newTestTreasury(t)
+
+	planner, err := NewMultisigSigningPlanner(tr.treasury, tr.graph)
+	assert.Nil(t, err)
+
+	conf, err := NewConfigWithReputation([]string{mockServer.GetServerURL()}, MijinTest, &defaultRepConfig, DefaultWebsocketReconnectionTimeout, &Hash{1}, DefaultFeeCalculationStrategy)
+	assert.Nil(t, err)
+	client := NewClient(nil, conf)
+
+	newTransfer := func() Transaction {
+		tx, err := client.NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{}, NewPlainMessage(""))
+		assert.Nil(t, err)
+		return tx
+	}
+
+	plan, err := planner.Plan(newTransfer(), tr.a, tr.b, tr.c, tr.d)
+	assert.Nil(t, err)
+	assert.True(t, plan.Sufficient)
+	assert.ElementsMatch(t, []*Account{tr.a, tr.b}, plan.Signers)
+
+	aggregate, err := client.NewMultisigAggregateTransaction(fakeDeadline, plan)
+	assert.Nil(t, err)
+	assert.Equal(t, AggregateCompleted, aggregate.Type)
+	assert.Equal(t, tr.treasury, aggregate.InnerTransactions[0].GetAbstractTransaction().Signer)
+
+	signed, err := plan.Sign(aggregate)
+	assert.Nil(t, err)
+	assert.Equal(t, AggregateCompleted, signed.EntityType)
+
+	plan, err = planner.Plan(newTransfer(), tr.c)
+	assert.Nil(t, err)
+	assert.False(t, plan.Sufficient)
+	assert.Equal(t, []*Account{tr.c}, plan.Signers)
+	assert.Len(t, plan.Missing[0], 1)
+
+	aggregate, err = client.NewMultisigAggregateTransaction(fakeDeadline, plan)
+	assert.Nil(t, err)
+	assert.Equal(t, AggregateBonded, aggregate.Type)
+
+	_, err = planner.Plan(newTransfer(), testMultisigOutsider(t))
+	assert.Equal(t, ErrNoEligibleSigners, err)
+}
+
+func TestMultisigSigningPlanner_ParentLevels(t *testing.T) {
+	tr := newTestTreasury(t)
+
+	// treasury cosigns fund together with outsider, graph of treasury contains fund at negative level
+	fund, outsider := testMultisigOutsider(t), testMultisigOutsider(t)
+	tr.graph.MultisigAccounts[0][0].MultisigAccounts = []*PublicAccount{fund.PublicAccount}
+	tr.graph.MultisigAccounts[-1] = []*MultisigAccountInfo{{
+		Account:       *fund.PublicAccount,
+		MinApproval:   2,
+		MinRemoval:    2,
+		Cosignatories: []*PublicAccount{tr.treasury, outsider.PublicAccount},
+	}}
+
+	planner, err := NewMultisigSigningPlanner(tr.treasury, tr.graph)
+	assert.Nil(t, err)
+
+	assert.ElementsMatch(t, []*PublicAccount{tr.a.PublicAccount, tr.b.PublicAccount, tr.c.PublicAccount, tr.d.PublicAccount}, planner.Signers())
+
+	plan, err := planner.Plan(&TransferTransaction{}, tr.a, outsider)
+	assert.Nil(t, err)
+	assert.False(t, plan.Sufficient)
+	assert.Equal(t, []*Account{tr.a}, plan.Signers)
+
+	_, err = planner.Plan(&TransferTransaction{}, outsider)
+	assert.Equal(t, ErrNoEligibleSigners, err)
+}
+
+func testMultisigOutsider(t *testing.T) *Account {
+	a, err := NewAccount(MijinTest, GenerationHash)
+	assert.Nil(t, err)
+	return a
+}
@@ -0,0 +1,538 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// used when network config doesn't define blockGenerationTargetTime
+const defaultBlockGenerationTime = 15 * time.Second
+
+// names of NetworkConfig sections
+const (
+	NetworkSection          = "network"
+	ChainSection            = "chain"
+	TransferPluginSection   = "plugin:catapult.plugins.transfer"
+	AggregatePluginSection  = "plugin:catapult.plugins.aggregate"
+	NamespacePluginSection  = "plugin:catapult.plugins.namespace"
+	MosaicPluginSection     = "plugin:catapult.plugins.mosaic"
+	LockHashPluginSection   = "plugin:catapult.plugins.lockhash"
+	LockSecretPluginSection = "plugin:catapult.plugins.locksecret"
+	MultisigPluginSection   = "plugin:catapult.plugins.multisig"
+	PropertyPluginSection   = "plugin:catapult.plugins.property"
+	MetadataPluginSection   = "plugin:catapult.plugins.metadata"
+	ConfigPluginSection     = "plugin:catapult.plugins.config"
+	UpgradePluginSection    = "plugin:catapult.plugins.upgrade"
+	ContractPluginSection   = "plugin:catapult.plugins.contract"
+	ExchangePluginSection   = "plugin:catapult.plugins.exchange"
+	StoragePluginSection    = "plugin:catapult.plugins.service"
+)
+
+// ConfigValueType defines how value of NetworkConfig field is parsed
+type ConfigValueType uint8
+
+// ConfigValueType enums
+const (
+	ConfigStringValue ConfigValueType = iota
+	// Integer which may be grouped with apostrophes, like 1'000
+	ConfigUint64Value
+	// Amount of mosaic units which may be grouped with apostrophes, like 8'999'999'998'000'000
+	ConfigAmountValue
+	// Time span with unit suffix ms, s, m, h or d, like 15s or 1h
+	ConfigDurationValue
+	ConfigBoolValue
+	// Hexadecimal id which may be grouped with apostrophes, like 0x0DC6'7FBE'1CAD'29E3
+	ConfigMosaicIdValue
+)
+
+func (t ConfigValueType) String() string {
+	switch t {
+	case ConfigUint64Value:
+		return "uint64"
+	case ConfigAmountValue:
+		return "amount"
+	case ConfigDurationValue:
+		return "duration"
+	case ConfigBoolValue:
+		return "bool"
+	case ConfigMosaicIdValue:
+		return "mosaic id"
+	default:
+		return "string"
+	}
+}
+
+// NetworkConfigSchema contains types of fields of known sections. Fields which are not described are kept as strings
+var NetworkConfigSchema = map[string]map[string]ConfigValueType{
+	NetworkSection: {
+		"identifier":     ConfigStringValue,
+		"publicKey":      ConfigStringValue,
+		"generationHash": ConfigStringValue,
+	},
+	ChainSection: {
+		"enableVerifiableState":        ConfigBoolValue,
+		"enableVerifiableReceipts":     ConfigBoolValue,
+		"currencyMosaicId":             ConfigMosaicIdValue,
+		"harvestingMosaicId":           ConfigMosaicIdValue,
+		"blockGenerationTargetTime":    ConfigDurationValue,
+		"blockTimeSmoothingFactor":     ConfigUint64Value,
+		"greedDelta":                   ConfigUint64Value,
+		"greedExponent":                ConfigUint64Value,
+		"importanceGrouping":           ConfigUint64Value,
+		"maxRollbackBlocks":            ConfigUint64Value,
+		"maxDifficultyBlocks":          ConfigUint64Value,
+		"maxTransactionLifetime":       ConfigDurationValue,
+		"maxBlockFutureTime":           ConfigDurationValue,
+		"initialCurrencyAtomicUnits":   ConfigAmountValue,
+		"maxMosaicAtomicUnits":         ConfigAmountValue,
+		"totalChainImportance":         ConfigAmountValue,
+		"minHarvesterBalance":          ConfigAmountValue,
+		"harvestBeneficiaryPercentage": ConfigUint64Value,
+		"blockPruneInterval":           ConfigUint64Value,
+		"maxTransactionsPerBlock":      ConfigUint64Value,
+	},
+	TransferPluginSection: {
+		"maxMessageSize": ConfigUint64Value,
+		"maxMosaicsSize": ConfigUint64Value,
+	},
+	AggregatePluginSection: {
+		"maxTransactionsPerAggregate":  ConfigUint64Value,
+		"maxCosignaturesPerAggregate":  ConfigUint64Value,
+		"enableStrictCosignatureCheck": ConfigBoolValue,
+		"enableBondedAggregateSupport": ConfigBoolValue,
+		"maxBondedTransactionLifetime": ConfigDurationValue,
+	},
+	NamespacePluginSection: {
+		"maxNameSize":                     ConfigUint64Value,
+		"maxNamespaceDuration":            ConfigDurationValue,
+		"namespaceGracePeriodDuration":    ConfigDurationValue,
+		"reservedRootNamespaceNames":      ConfigStringValue,
+		"namespaceRentalFeeSinkPublicKey": ConfigStringValue,
+		"rootNamespaceRentalFeePerBlock":  ConfigAmountValue,
+		"childNamespaceRentalFee":         ConfigAmountValue,
+		"maxChildNamespaces":              ConfigUint64Value,
+		"maxNamespaceDepth":               ConfigUint64Value,
+	},
+	MosaicPluginSection: {
+		"maxMosaicsPerAccount":         ConfigUint64Value,
+		"maxMosaicDuration":            ConfigDurationValue,
+		"maxMosaicDivisibility":        ConfigUint64Value,
+		"mosaicRentalFeeSinkPublicKey": ConfigStringValue,
+		"mosaicRentalFee":              ConfigAmountValue,
+	},
+	LockHashPluginSection: {
+		"lockedFundsPerAggregate": ConfigAmountValue,
+		"maxHashLockDuration":     ConfigDurationValue,
+	},
+	LockSecretPluginSection: {
+		"maxSecretLockDuration": ConfigDurationValue,
+		"minProofSize":          ConfigUint64Value,
+		"maxProofSize":          ConfigUint64Value,
+	},
+	MultisigPluginSection: {
+		"maxMultisigDepth":              ConfigUint64Value,
+		"maxCosignersPerAccount":        ConfigUint64Value,
+		"maxCosignedAccountsPerAccount": ConfigUint64Value,
+	},
+	PropertyPluginSection: {
+		"maxPropertyValues": ConfigUint64Value,
+	},
+	MetadataPluginSection: {
+		"maxFields":         ConfigUint64Value,
+		"maxFieldKeySize":   ConfigUint64Value,
+		"maxFieldValueSize": ConfigUint64Value,
+	},
+	ConfigPluginSection: {
+		"maxBlockChainConfigSize":        ConfigUint64Value,
+		"maxSupportedEntityVersionsSize": ConfigUint64Value,
+	},
+	UpgradePluginSection: {
+		"minUpgradePeriod": ConfigUint64Value,
+	},
+	ContractPluginSection: {
+		"modifyContractTransactionSupported": ConfigBoolValue,
+	},
+	ExchangePluginSection: {
+		"maxOfferDuration": ConfigUint64Value,
+		"longOfferKey":     ConfigStringValue,
+	},
+	StoragePluginSection: {
+		"maxFilesOnDrive":      ConfigUint64Value,
+		"verificationFee":      ConfigAmountValue,
+		"verificationDuration": ConfigUint64Value,
+	},
+}
+
+// returns section with passed name
+func (c *NetworkConfig) Section(name string) (*ConfigBag, error) {
+	bag, ok := c.Sections[name]
+	if !ok {
+		return nil, errors.Wrapf(ErrConfigSectionNotFound, "section %s", name)
+	}
+
+	return bag, nil
+}
+
+// sets raw value of field. Missing section and field are appended after existing ones
+func (c *NetworkConfig) Set(section, key, value string) {
+	bag, ok := c.Sections[section]
+	if !ok {
+		bag = NewConfigBag()
+		bag.Name = section
+		bag.Index = len(c.Sections)
+		c.Sections[section] = bag
+	}
+
+	bag.Set(key, value)
+}
+
+// returns raw value of field
+func (c *NetworkConfig) Get(section, key string) (string, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return "", err
+	}
+
+	return bag.Get(key)
+}
+
+func (c *NetworkConfig) GetUint64(section, key string) (uint64, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return 0, err
+	}
+
+	return bag.GetUint64(key)
+}
+
+func (c *NetworkConfig) GetAmount(section, key string) (Amount, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return 0, err
+	}
+
+	return bag.GetAmount(key)
+}
+
+func (c *NetworkConfig) GetDuration(section, key string) (time.Duration, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return 0, err
+	}
+
+	return bag.GetDuration(key)
+}
+
+func (c *NetworkConfig) GetBool(section, key string) (bool, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return false, err
+	}
+
+	return bag.GetBool(key)
+}
+
+func (c *NetworkConfig) GetMosaicId(section, key string) (*MosaicId, error) {
+	bag, err := c.Section(section)
+	if err != nil {
+		return nil, err
+	}
+
+	return bag.GetMosaicId(key)
+}
+
+// returns target time between blocks
+func (c *NetworkConfig) BlockGenerationTargetTime() (time.Duration, error) {
+	return c.GetDuration(ChainSection, "blockGenerationTargetTime")
+}
+
+// returns mosaic in which fees are paid
+func (c *NetworkConfig) CurrencyMosaicId() (*MosaicId, error) {
+	return c.GetMosaicId(ChainSection, "currencyMosaicId")
+}
+
+// returns amount of currency which is locked by hash lock of bonded aggregate
+func (c *NetworkConfig) LockedFundsPerAggregate() (Amount, error) {
+	return c.GetAmount(LockHashPluginSection, "lockedFundsPerAggregate")
+}
+
+// returns maximum duration of hash lock
+func (c *NetworkConfig) MaxHashLockDuration() (time.Duration, error) {
+	return c.GetDuration(LockHashPluginSection, "maxHashLockDuration")
+}
+
+// checks that values of all fields described in NetworkConfigSchema can be parsed. Unknown fields are not checked
+func (c *NetworkConfig) Validate() error {
+	for _, name := range c.sectionNames() {
+		schema, ok := NetworkConfigSchema[name]
+		if !ok {
+			continue
+		}
+
+		bag := c.Sections[name]
+		for _, field := range bag.sortedFields() {
+			t, ok := schema[field.Key]
+			if !ok {
+				continue
+			}
+
+			if err := parseConfigValue(t, field.Value); err != nil {
+				return errors.Wrapf(err, "section %s, field %s", name, field.Key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// returns keys of fields which are not described in NetworkConfigSchema grouped by section.
+// Unknown sections are returned with all their keys
+func (c *NetworkConfig) UnknownFields() map[string][]string {
+	unknown := make(map[string][]string)
+	for _, name := range c.sectionNames() {
+		schema := NetworkConfigSchema[name]
+		for _, field := range c.Sections[name].sortedFields() {
+			if _, ok := schema[field.Key]; !ok {
+				unknown[name] = append(unknown[name], field.Key)
+			}
+		}
+	}
+
+	return unknown
+}
+
+func (c *NetworkConfig) sectionNames() []string {
+	names := make([]string, 0, len(c.Sections))
+	for name := range c.Sections {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return c.Sections[names[i]].Index < c.Sections[names[j]].Index
+	})
+
+	return names
+}
+
+func (c *ConfigBag) sortedFields() []*Field {
+	fields := make([]*Field, 0, len(c.Fields))
+	for _, f := range c.Fields {
+		fields = append(fields, f)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Index < fields[j].Index
+	})
+
+	return fields
+}
+
+// returns raw value of field
+func (c *ConfigBag) Get(key string) (string, error) {
+	field, ok := c.Fields[key]
+	if !ok {
+		return "", errors.Wrapf(ErrConfigFieldNotFound, "section %s, field %s", c.Name, key)
+	}
+
+	return field.Value, nil
+}
+
+// sets raw value of field keeping its comment and position. Missing field is appended after existing ones
+func (c *ConfigBag) Set(key, value string) {
+	if field, ok := c.Fields[key]; ok {
+		field.Value = value
+		return
+	}
+
+	c.Fields[key] = &Field{Key: key, Value: value, Index: len(c.Fields)}
+}
+
+func (c *ConfigBag) GetUint64(key string) (uint64, error) {
+	v, err := c.Get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseConfigUint64(v)
+}
+
+func (c *ConfigBag) GetAmount(key string) (Amount, error) {
+	v, err := c.GetUint64(key)
+	return Amount(v), err
+}
+
+func (c *ConfigBag) GetDuration(key string) (time.Duration, error) {
+	v, err := c.Get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseConfigDuration(v)
+}
+
+func (c *ConfigBag) GetBool(key string) (bool, error) {
+	v, err := c.Get(key)
+	if err != nil {
+		return false, err
+	}
+
+	return parseConfigBool(v)
+}
+
+func (c *ConfigBag) GetMosaicId(key string) (*MosaicId, error) {
+	v, err := c.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseConfigHex(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMosaicId(id)
+}
+
+func (c *ConfigBag) SetUint64(key string, value uint64) {
+	c.Set(key, strconv.FormatUint(value, 10))
+}
+
+// sets amount grouped with apostrophes like node does
+func (c *ConfigBag) SetAmount(key string, value Amount) {
+	c.Set(key, formatConfigAmount(uint64(value)))
+}
+
+// sets duration with the biggest unit which represents it exactly
+func (c *ConfigBag) SetDuration(key string, value time.Duration) {
+	c.Set(key, formatConfigDuration(value))
+}
+
+func (c *ConfigBag) SetBool(key string, value bool) {
+	c.Set(key, strconv.FormatBool(value))
+}
+
+func (c *ConfigBag) SetMosaicId(key string, value *MosaicId) {
+	c.Set(key, formatConfigHex(value.Id()))
+}
+
+// returns true when error is caused by missing section or field
+func isConfigNotFound(err error) bool {
+	cause := errors.Cause(err)
+	return cause == ErrConfigSectionNotFound || cause == ErrConfigFieldNotFound
+}
+
+func parseConfigValue(t ConfigValueType, value string) error {
+	var err error
+	switch t {
+	case ConfigUint64Value, ConfigAmountValue:
+		_, err = parseConfigUint64(value)
+	case ConfigDurationValue:
+		_, err = parseConfigDuration(value)
+	case ConfigBoolValue:
+		_, err = parseConfigBool(value)
+	case ConfigMosaicIdValue:
+		_, err = parseConfigHex(value)
+	}
+
+	return err
+}
+
+func parseConfigUint64(value string) (uint64, error) {
+	v, err := strconv.ParseUint(strings.Replace(value, "'", "", -1), 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(ErrConfigValueType, "%s is not %s", value, ConfigUint64Value)
+	}
+
+	return v, nil
+}
+
+func parseConfigHex(value string) (uint64, error) {
+	v := strings.Replace(value, "'", "", -1)
+	if !strings.HasPrefix(v, "0x") && !strings.HasPrefix(v, "0X") {
+		return 0, errors.Wrapf(ErrConfigValueType, "%s is not %s", value, ConfigMosaicIdValue)
+	}
+
+	id, err := strconv.ParseUint(v[2:], 16, 64)
+	if err != nil {
+		return 0, errors.Wrapf(ErrConfigValueType, "%s is not %s", value, ConfigMosaicIdValue)
+	}
+
+	return id, nil
+}
+
+func parseConfigBool(value string) (bool, error) {
+	switch value {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, errors.Wrapf(ErrConfigValueType, "%s is not %s", value, ConfigBoolValue)
+	}
+}
+
+var configDurationUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	// ms should be checked before m and s
+	{"ms", time.Millisecond},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+func parseConfigDuration(value string) (time.Duration, error) {
+	for _, u := range configDurationUnits {
+		if !strings.HasSuffix(value, u.suffix) {
+			continue
+		}
+
+		v, err := parseConfigUint64(strings.TrimSuffix(value, u.suffix))
+		if err != nil {
+			break
+		}
+
+		return time.Duration(v) * u.unit, nil
+	}
+
+	return 0, errors.Wrapf(ErrConfigValueType, "%s is not %s", value, ConfigDurationValue)
+}
+
+func formatConfigDuration(value time.Duration) string {
+	if value == 0 {
+		return "0s"
+	}
+
+	for _, u := range configDurationUnits[1:] {
+		if value%u.unit == 0 {
+			return fmt.Sprintf("%d%s", value/u.unit, u.suffix)
+		}
+	}
+
+	return fmt.Sprintf("%dms", value/time.Millisecond)
+}
+
+func formatConfigAmount(value uint64) string {
+	s := strconv.FormatUint(value, 10)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "'" + s[i:]
+	}
+
+	return s
+}
+
+func formatConfigHex(value uint64) string {
+	s := fmt.Sprintf("%016X", value)
+	return fmt.Sprintf("0x%s'%s'%s'%s", s[0:4], s[4:8], s[8:12], s[12:16])
+}
@@ -0,0 +1,433 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// time after which proposal is applied when it is not set
+const defaultConfigApplyAfter = time.Hour
+
+// sections without which node can't work
+var requiredNetworkConfigSections = []string{NetworkSection, ChainSection}
+
+// ChangeKind describes how value differs between two configs
+type ChangeKind uint8
+
+// ChangeKind enums
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeModified
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// ConfigFieldChange is a difference of one field of network config
+type ConfigFieldChange struct {
+	Section  string
+	Key      string
+	Kind     ChangeKind
+	OldValue string
+	NewValue string
+}
+
+func (c *ConfigFieldChange) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("%s [%s] %s = %s", c.Kind, c.Section, c.Key, c.NewValue)
+	case ChangeRemoved:
+		return fmt.Sprintf("%s [%s] %s = %s", c.Kind, c.Section, c.Key, c.OldValue)
+	default:
+		return fmt.Sprintf("%s [%s] %s = %s -> %s", c.Kind, c.Section, c.Key, c.OldValue, c.NewValue)
+	}
+}
+
+// NetworkConfigDiff is a structural difference between two network configs ordered by section and key
+type NetworkConfigDiff struct {
+	AddedSections   []string
+	RemovedSections []string
+	Fields          []*ConfigFieldChange
+}
+
+// returns true when configs have the same sections and values. Comments and order of fields are ignored
+func (d *NetworkConfigDiff) Empty() bool {
+	return len(d.AddedSections) == 0 && len(d.RemovedSections) == 0 && len(d.Fields) == 0
+}
+
+// returns changes of fields of passed section
+func (d *NetworkConfigDiff) Section(name string) []*ConfigFieldChange {
+	changes := make([]*ConfigFieldChange, 0)
+	for _, c := range d.Fields {
+		if c.Section == name {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+func (d *NetworkConfigDiff) String() string {
+	lines := make([]string, 0, len(d.Fields))
+	for _, c := range d.Fields {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// returns difference which turns old config into new one
+func DiffNetworkConfigs(old, next *NetworkConfig) *NetworkConfigDiff {
+	diff := &NetworkConfigDiff{
+		AddedSections:   make([]string, 0),
+		RemovedSections: make([]string, 0),
+		Fields:          make([]*ConfigFieldChange, 0),
+	}
+
+	names := make(map[string]bool)
+	for name := range old.Sections {
+		names[name] = true
+	}
+	for name := range next.Sections {
+		names[name] = true
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		oldBag, inOld := old.Sections[name]
+		newBag, inNew := next.Sections[name]
+
+		switch {
+		case !inOld:
+			diff.AddedSections = append(diff.AddedSections, name)
+			oldBag = NewConfigBag()
+		case !inNew:
+			diff.RemovedSections = append(diff.RemovedSections, name)
+			newBag = NewConfigBag()
+		}
+
+		diff.Fields = append(diff.Fields, diffConfigBags(name, oldBag, newBag)...)
+	}
+
+	return diff
+}
+
+// values of fields described in NetworkConfigSchema are compared parsed, so 1'000 and 1000 or 15s and 15000ms are equal
+func diffConfigBags(section string, old, next *ConfigBag) []*ConfigFieldChange {
+	schema := NetworkConfigSchema[section]
+
+	keys := make(map[string]bool)
+	for key := range old.Fields {
+		keys[key] = true
+	}
+	for key := range next.Fields {
+		keys[key] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	changes := make([]*ConfigFieldChange, 0)
+	for _, key := range sorted {
+		oldField, inOld := old.Fields[key]
+		newField, inNew := next.Fields[key]
+
+		switch {
+		case !inOld:
+			changes = append(changes, &ConfigFieldChange{Section: section, Key: key, Kind: ChangeAdded, NewValue: newField.Value})
+		case !inNew:
+			changes = append(changes, &ConfigFieldChange{Section: section, Key: key, Kind: ChangeRemoved, OldValue: oldField.Value})
+		case !equalConfigValues(schema[key], oldField.Value, newField.Value):
+			changes = append(changes, &ConfigFieldChange{
+				Section:  section,
+				Key:      key,
+				Kind:     ChangeModified,
+				OldValue: oldField.Value,
+				NewValue: newField.Value,
+			})
+		}
+	}
+
+	return changes
+}
+
+// values which can't be parsed by their type are compared as strings
+func equalConfigValues(t ConfigValueType, a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var (
+		x, y       interface{}
+		errA, errB error
+	)
+
+	switch t {
+	case ConfigUint64Value, ConfigAmountValue:
+		x, errA = parseConfigUint64(a)
+		y, errB = parseConfigUint64(b)
+	case ConfigDurationValue:
+		x, errA = parseConfigDuration(a)
+		y, errB = parseConfigDuration(b)
+	case ConfigBoolValue:
+		x, errA = parseConfigBool(a)
+		y, errB = parseConfigBool(b)
+	case ConfigMosaicIdValue:
+		x, errA = parseConfigHex(a)
+		y, errB = parseConfigHex(b)
+	default:
+		return false
+	}
+
+	return errA == nil && errB == nil && x == y
+}
+
+// EntityChange is a difference of supported versions of one entity
+type EntityChange struct {
+	Type        EntityType
+	Name        string
+	Kind        ChangeKind
+	OldVersions []EntityVersion
+	NewVersions []EntityVersion
+}
+
+func (c *EntityChange) String() string {
+	return fmt.Sprintf("%s %s(%d) %v -> %v", c.Kind, c.Name, c.Type, c.OldVersions, c.NewVersions)
+}
+
+// SupportedEntitiesDiff is a difference between two sets of supported entities ordered by entity type
+type SupportedEntitiesDiff struct {
+	Entities []*EntityChange
+}
+
+func (d *SupportedEntitiesDiff) Empty() bool {
+	return len(d.Entities) == 0
+}
+
+func (d *SupportedEntitiesDiff) String() string {
+	lines := make([]string, 0, len(d.Entities))
+	for _, c := range d.Entities {
+		lines = append(lines, c.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// returns difference which turns old supported entities into new ones
+func DiffSupportedEntities(old, next *SupportedEntities) *SupportedEntitiesDiff {
+	types := make(map[EntityType]bool)
+	for t := range old.Entities {
+		types[t] = true
+	}
+	for t := range next.Entities {
+		types[t] = true
+	}
+
+	sorted := make([]EntityType, 0, len(types))
+	for t := range types {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	diff := &SupportedEntitiesDiff{Entities: make([]*EntityChange, 0)}
+	for _, t := range sorted {
+		oldEntity, inOld := old.Entities[t]
+		newEntity, inNew := next.Entities[t]
+
+		switch {
+		case !inOld:
+			diff.Entities = append(diff.Entities, &EntityChange{
+				Type: t, Name: newEntity.Name, Kind: ChangeAdded, NewVersions: newEntity.SupportedVersions,
+			})
+		case !inNew:
+			diff.Entities = append(diff.Entities, &EntityChange{
+				Type: t, Name: oldEntity.Name, Kind: ChangeRemoved, OldVersions: oldEntity.SupportedVersions,
+			})
+		case !equalEntityVersions(oldEntity.SupportedVersions, newEntity.SupportedVersions):
+			diff.Entities = append(diff.Entities, &EntityChange{
+				Type:        t,
+				Name:        newEntity.Name,
+				Kind:        ChangeModified,
+				OldVersions: oldEntity.SupportedVersions,
+				NewVersions: newEntity.SupportedVersions,
+			})
+		}
+	}
+
+	return diff
+}
+
+// versions are compared as sets
+func equalEntityVersions(a, b []EntityVersion) bool {
+	set := make(map[EntityVersion]bool, len(a))
+	for _, v := range a {
+		set[v] = true
+	}
+
+	other := make(map[EntityVersion]bool, len(b))
+	for _, v := range b {
+		if !set[v] {
+			return false
+		}
+		other[v] = true
+	}
+
+	return len(set) == len(other)
+}
+
+// NetworkConfigProposal describes change of network config. Nil Config or Entities keep the current ones
+type NetworkConfigProposal struct {
+	Config   *NetworkConfig
+	Entities *SupportedEntities
+	// Time after which proposal is applied. It is converted to blocks with current block generation time.
+	// Zero means one hour
+	ApplyAfter time.Duration
+}
+
+// NetworkConfigChange is a validated proposal with its difference from the current config
+type NetworkConfigChange struct {
+	Transaction  *NetworkConfigTransaction
+	ConfigDiff   *NetworkConfigDiff
+	EntitiesDiff *SupportedEntitiesDiff
+}
+
+// returns NetworkConfigTransaction for proposal validated against the current config.
+// Proposal is rejected when it doesn't change anything, misses required sections, has values of wrong types
+// or makes NetworkConfigTransaction unsupported
+func NewNetworkConfigChange(deadline *Deadline, current *BlockchainConfig, proposal *NetworkConfigProposal, networkType NetworkType) (*NetworkConfigChange, error) {
+	if current == nil || current.NetworkConfig == nil || current.SupportedEntityVersions == nil || proposal == nil {
+		return nil, ErrNilNetworkConfig
+	}
+
+	config, entities := proposal.Config, proposal.Entities
+	if config == nil {
+		config = current.NetworkConfig
+	}
+	if entities == nil {
+		entities = current.SupportedEntityVersions
+	}
+
+	if err := validateNetworkConfigProposal(config, entities); err != nil {
+		return nil, err
+	}
+
+	change := &NetworkConfigChange{
+		ConfigDiff:   DiffNetworkConfigs(current.NetworkConfig, config),
+		EntitiesDiff: DiffSupportedEntities(current.SupportedEntityVersions, entities),
+	}
+
+	if change.ConfigDiff.Empty() && change.EntitiesDiff.Empty() {
+		return nil, ErrNoChanges
+	}
+
+	delta, err := applyHeightDelta(current.NetworkConfig, proposal.ApplyAfter)
+	if err != nil {
+		return nil, err
+	}
+
+	change.Transaction, err = NewNetworkConfigTransaction(deadline, delta, config, entities, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+// returns NetworkConfigChange for proposal validated against the current config of network
+func (c *Client) NewNetworkConfigChange(ctx context.Context, deadline *Deadline, proposal *NetworkConfigProposal) (*NetworkConfigChange, error) {
+	current, err := c.Network.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := NewNetworkConfigChange(deadline, current, proposal, c.config.NetworkType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(change.Transaction); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func validateNetworkConfigProposal(config *NetworkConfig, entities *SupportedEntities) error {
+	for _, name := range requiredNetworkConfigSections {
+		if _, err := config.Section(name); err != nil {
+			return err
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := config.BlockGenerationTargetTime(); err != nil {
+		return err
+	}
+
+	for _, entity := range entities.Entities {
+		if len(entity.SupportedVersions) == 0 {
+			return errors.Wrapf(ErrEmptyEntityVersions, "entity %s", entity.Name)
+		}
+	}
+
+	if _, ok := entities.Entities[NetworkConfigEntityType]; !ok {
+		return ErrNetworkConfigUnsupported
+	}
+
+	return nil
+}
+
+// returns number of blocks generated during applyAfter, but not less than number of blocks which can be rolled back
+func applyHeightDelta(current *NetworkConfig, applyAfter time.Duration) (Duration, error) {
+	if applyAfter <= 0 {
+		applyAfter = defaultConfigApplyAfter
+	}
+
+	blockTime, err := current.BlockGenerationTargetTime()
+	if isConfigNotFound(err) {
+		blockTime, err = defaultBlockGenerationTime, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	delta := uint64((applyAfter + blockTime - 1) / blockTime)
+
+	rollback, err := current.GetUint64(ChainSection, "maxRollbackBlocks")
+	if err == nil && delta <= rollback {
+		delta = rollback + 1
+	}
+
+	return Duration(delta), nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func testSupportedEntities() *SupportedEntities {
+	entities := NewSupportedEntities()
+	entities.Entities[NetworkConfigEntityType] = &Entity{
+		Name: "Network_Config", Type: NetworkConfigEntityType, SupportedVersions: []EntityVersion{1},
+	}
+	entities.Entities[Transfer] = &Entity{
+		Name: "Transfer", Type: Transfer, SupportedVersions: []EntityVersion{3},
+	}
+
+	return entities
+}
+
+func testBlockchainConfig(t *testing.T) *BlockchainConfig {
+	return &BlockchainConfig{
+		StartedHeight:           1,
+		NetworkConfig:           testNetworkConfig(t),
+		SupportedEntityVersions: testSupportedEntities(),
+	}
+}
+
+func TestDiffNetworkConfigs(t *testing.T) {
+	old := testNetworkConfig(t)
+	proposed := testNetworkConfig(t)
+
+	assert.True(t, DiffNetworkConfigs(old, proposed).Empty())
+
+	proposed.Set(ChainSection, "blockGenerationTargetTime", "30s")
+	proposed.Set(ChainSection, "maxRollbackBlocks", "40")
+	delete(proposed.Sections[ChainSection].Fields, "customChainSetting")
+	delete(proposed.Sections, "plugin:catapult.plugins.custom")
+	proposed.Set(MosaicPluginSection, "maxMosaicDivisibility", "6")
+
+	diff := DiffNetworkConfigs(old, proposed)
+	assert.False(t, diff.Empty())
+	assert.Equal(t, []string{MosaicPluginSection}, diff.AddedSections)
+	assert.Equal(t, []string{"plugin:catapult.plugins.custom"}, diff.RemovedSections)
+	assert.Equal(t, []*ConfigFieldChange{
+		{Section: ChainSection, Key: "blockGenerationTargetTime", Kind: ChangeModified, OldValue: "15s", NewValue: "30s"},
+		{Section: ChainSection, Key: "customChainSetting", Kind: ChangeRemoved, OldValue: "keep me"},
+		{Section: ChainSection, Key: "maxRollbackBlocks", Kind: ChangeAdded, NewValue: "40"},
+	}, diff.Section(ChainSection))
+	assert.Len(t, diff.Fields, 5)
+	assert.Contains(t, diff.String(), "~ [chain] blockGenerationTargetTime = 15s -> 30s")
+}
+
+func TestDiffNetworkConfigs_EquivalentValues(t *testing.T) {
+	old := testNetworkConfig(t)
+	proposed := testNetworkConfig(t)
+
+	proposed.Set(ChainSection, "blockGenerationTargetTime", "15000ms")
+	proposed.Set(ChainSection, "maxTransactionLifetime", "1d")
+	proposed.Set(ChainSection, "maxMosaicAtomicUnits", "9000000000000000")
+	proposed.Set(ChainSection, "currencyMosaicId", "0x0dc67fbe1cad29e3")
+	proposed.Set(LockHashPluginSection, "lockedFundsPerAggregate", "10000000")
+	assert.True(t, DiffNetworkConfigs(old, proposed).Empty())
+
+	_, err := NewNetworkConfigChange(fakeDeadline, &BlockchainConfig{
+		NetworkConfig:           old,
+		SupportedEntityVersions: testSupportedEntities(),
+	}, &NetworkConfigProposal{Config: proposed}, MijinTest)
+	assert.Equal(t, ErrNoChanges, err)
+
+	// fields which aren't described in schema are compared as strings
+	proposed.Set(ChainSection, "customChainSetting", "keep  me")
+	assert.Len(t, DiffNetworkConfigs(old, proposed).Fields, 1)
+}
+
+func TestDiffSupportedEntities(t *testing.T) {
+	old := testSupportedEntities()
+	proposed := testSupportedEntities()
+	proposed.Entities[NetworkConfigEntityType].SupportedVersions = []EntityVersion{1}
+
+	assert.True(t, DiffSupportedEntities(old, proposed).Empty())
+
+	proposed.Entities[Transfer] = &Entity{Name: "Transfer", Type: Transfer, SupportedVersions: []EntityVersion{3, 4}}
+	proposed.Entities[MosaicDefinition] = &Entity{Name: "Mosaic_Definition", Type: MosaicDefinition, SupportedVersions: []EntityVersion{3}}
+
+	diff := DiffSupportedEntities(old, proposed)
+	assert.Len(t, diff.Entities, 2)
+	assert.Equal(t, ChangeModified, diff.Entities[1].Kind)
+	assert.Equal(t, []EntityVersion{3, 4}, diff.Entities[1].NewVersions)
+	assert.Equal(t, ChangeAdded, diff.Entities[0].Kind)
+}
+
+func TestNewNetworkConfigChange(t *testing.T) {
+	current := testBlockchainConfig(t)
+
+	proposed := testNetworkConfig(t)
+	proposed.Set(ChainSection, "maxTransactionLifetime", "12h")
+
+	change, err := NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Config: proposed}, MijinTest)
+	assert.Nil(t, err)
+	assert.Len(t, change.ConfigDiff.Fields, 1)
+	assert.True(t, change.EntitiesDiff.Empty())
+	// one hour of 15 seconds blocks
+	assert.Equal(t, Duration(240), change.Transaction.ApplyHeightDelta)
+	assert.Equal(t, current.SupportedEntityVersions, change.Transaction.SupportedEntities)
+
+	current.NetworkConfig.Set(ChainSection, "maxRollbackBlocks", "398")
+	proposed.Set(ChainSection, "maxRollbackBlocks", "398")
+	change, err = NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Config: proposed, ApplyAfter: time.Minute}, MijinTest)
+	assert.Nil(t, err)
+	assert.Equal(t, Duration(399), change.Transaction.ApplyHeightDelta)
+}
+
+func TestNewNetworkConfigChange_Rejected(t *testing.T) {
+	current := testBlockchainConfig(t)
+
+	_, err := NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Config: testNetworkConfig(t)}, MijinTest)
+	assert.Equal(t, ErrNoChanges, err)
+
+	proposed := testNetworkConfig(t)
+	delete(proposed.Sections, ChainSection)
+	_, err = NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Config: proposed}, MijinTest)
+	assert.Equal(t, ErrConfigSectionNotFound, errors.Cause(err))
+
+	proposed = testNetworkConfig(t)
+	proposed.Set(LockHashPluginSection, "lockedFundsPerAggregate", "ten")
+	_, err = NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Config: proposed}, MijinTest)
+	assert.Equal(t, ErrConfigValueType, errors.Cause(err))
+
+	entities := testSupportedEntities()
+	delete(entities.Entities, NetworkConfigEntityType)
+	_, err = NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Entities: entities}, MijinTest)
+	assert.Equal(t, ErrNetworkConfigUnsupported, err)
+
+	entities = testSupportedEntities()
+	entities.Entities[Transfer].SupportedVersions = nil
+	_, err = NewNetworkConfigChange(fakeDeadline, current, &NetworkConfigProposal{Entities: entities}, MijinTest)
+	assert.Equal(t, ErrEmptyEntityVersions, errors.Cause(err))
+}
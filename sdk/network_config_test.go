@@ -0,0 +1,136 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const testNetworkConfigText = `[network]
+identifier = mijin-test
+
+[chain]
+enableVerifiableState = true
+currencyMosaicId = 0x0DC6'7FBE'1CAD'29E3
+blockGenerationTargetTime = 15s
+maxTransactionLifetime = 24h
+maxMosaicAtomicUnits = 9'000'000'000'000'000
+customChainSetting = keep me
+
+# hash lock settings
+[plugin:catapult.plugins.lockhash]
+lockedFundsPerAggregate = 10'000'000
+maxHashLockDuration = 2d
+
+[plugin:catapult.plugins.custom]
+someKey = someValue
+`
+
+func testNetworkConfig(t *testing.T) *NetworkConfig {
+	c := NewNetworkConfig()
+	assert.Nil(t, c.UnmarshalBinary([]byte(testNetworkConfigText)))
+	return c
+}
+
+func TestNetworkConfig_TypedAccessors(t *testing.T) {
+	c := testNetworkConfig(t)
+
+	d, err := c.BlockGenerationTargetTime()
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Second, d)
+
+	d, err = c.GetDuration(ChainSection, "maxTransactionLifetime")
+	assert.Nil(t, err)
+	assert.Equal(t, 24*time.Hour, d)
+
+	d, err = c.MaxHashLockDuration()
+	assert.Nil(t, err)
+	assert.Equal(t, 48*time.Hour, d)
+
+	amount, err := c.GetAmount(ChainSection, "maxMosaicAtomicUnits")
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(9000000000000000), amount)
+
+	amount, err = c.LockedFundsPerAggregate()
+	assert.Nil(t, err)
+	assert.Equal(t, Amount(10000000), amount)
+
+	b, err := c.GetBool(ChainSection, "enableVerifiableState")
+	assert.Nil(t, err)
+	assert.True(t, b)
+
+	mosaicId, err := c.CurrencyMosaicId()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0x0DC67FBE1CAD29E3), mosaicId.Id())
+
+	_, err = c.GetBool(ChainSection, "blockGenerationTargetTime")
+	assert.Equal(t, ErrConfigValueType, errors.Cause(err))
+
+	_, err = c.GetUint64(ChainSection, "missing")
+	assert.Equal(t, ErrConfigFieldNotFound, errors.Cause(err))
+
+	_, err = c.GetUint64(MosaicPluginSection, "maxMosaicDuration")
+	assert.Equal(t, ErrConfigSectionNotFound, errors.Cause(err))
+}
+
+func TestNetworkConfig_Validate(t *testing.T) {
+	c := testNetworkConfig(t)
+	assert.Nil(t, c.Validate())
+
+	c.Set(LockHashPluginSection, "maxHashLockDuration", "2 days")
+	assert.Equal(t, ErrConfigValueType, errors.Cause(c.Validate()))
+}
+
+func TestNetworkConfig_UnknownFieldsRoundTrip(t *testing.T) {
+	c := testNetworkConfig(t)
+
+	assert.Equal(t, map[string][]string{
+		ChainSection:                     {"customChainSetting"},
+		"plugin:catapult.plugins.custom": {"someKey"},
+	}, c.UnknownFields())
+
+	chain, err := c.Section(ChainSection)
+	assert.Nil(t, err)
+	chain.SetDuration("blockGenerationTargetTime", 30*time.Second)
+	chain.SetAmount("maxMosaicAtomicUnits", 1000000)
+	chain.SetBool("enableVerifiableReceipts", false)
+	c.Set(MosaicPluginSection, "maxMosaicDuration", formatConfigDuration(3650*24*time.Hour))
+
+	data, err := c.MarshalBinary()
+	assert.Nil(t, err)
+
+	restored := NewNetworkConfig()
+	assert.Nil(t, restored.UnmarshalBinary(data))
+	assert.Equal(t, c.String(), restored.String())
+
+	v, err := restored.Get(ChainSection, "customChainSetting")
+	assert.Nil(t, err)
+	assert.Equal(t, "keep me", v)
+
+	v, err = restored.Get(ChainSection, "maxMosaicAtomicUnits")
+	assert.Nil(t, err)
+	assert.Equal(t, "1'000'000", v)
+
+	v, err = restored.Get(MosaicPluginSection, "maxMosaicDuration")
+	assert.Nil(t, err)
+	assert.Equal(t, "3650d", v)
+
+	d, err := restored.BlockGenerationTargetTime()
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, d)
+}
+
+func TestFormatConfigDuration(t *testing.T) {
+	assert.Equal(t, "0s", formatConfigDuration(0))
+	assert.Equal(t, "500ms", formatConfigDuration(500*time.Millisecond))
+	assert.Equal(t, "90s", formatConfigDuration(90*time.Second))
+	assert.Equal(t, "2m", formatConfigDuration(2*time.Minute))
+	assert.Equal(t, "1h", formatConfigDuration(time.Hour))
+	assert.Equal(t, "0x0DC6'7FBE'1CAD'29E3", formatConfigHex(0x0DC67FBE1CAD29E3))
+}
@@ -265,6 +265,27 @@ func NewSupportedEntities() *SupportedEntities {
 	return ref
 }
 
+// returns the highest of passed versions which network supports for entity.
+// Returns false when network supports none of them
+func (s *SupportedEntities) HighestVersion(entityType EntityType, versions []EntityVersion) (EntityVersion, bool) {
+	entity, ok := s.Entities[entityType]
+	if !ok {
+		return 0, false
+	}
+
+	var highest EntityVersion
+	found := false
+	for _, v := range versions {
+		for _, supported := range entity.SupportedVersions {
+			if v == supported && (!found || v > highest) {
+				highest, found = v, true
+			}
+		}
+	}
+
+	return highest, found
+}
+
 func (s *SupportedEntities) UnmarshalBinary(data []byte) error {
 	dto := supportedEntitiesDTO{}
 	err := json.Unmarshal(data, &dto)
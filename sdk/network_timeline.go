@@ -0,0 +1,164 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+)
+
+// NetworkTimeline contains network configs and blockchain versions ordered by height from which they take effect
+type NetworkTimeline struct {
+	Configs  []*BlockchainConfig
+	Versions []*NetworkVersion
+}
+
+// returns config which is active at passed height or nil if the timeline doesn't cover the height
+func (t *NetworkTimeline) ConfigAt(height Height) *BlockchainConfig {
+	i := sort.Search(len(t.Configs), func(i int) bool {
+		return t.Configs[i].StartedHeight > height
+	})
+
+	if i == 0 {
+		return nil
+	}
+
+	return t.Configs[i-1]
+}
+
+// returns blockchain version which is active at passed height or nil if the timeline doesn't cover the height
+func (t *NetworkTimeline) VersionAt(height Height) *NetworkVersion {
+	i := sort.Search(len(t.Versions), func(i int) bool {
+		return t.Versions[i].StartedHeight > height
+	})
+
+	if i == 0 {
+		return nil
+	}
+
+	return t.Versions[i-1]
+}
+
+// returns difference of every config from the previous one. The first config is compared with an empty one
+func (t *NetworkTimeline) ConfigChanges() []*NetworkConfigDiff {
+	diffs := make([]*NetworkConfigDiff, 0, len(t.Configs))
+	previous := NewNetworkConfig()
+	for _, c := range t.Configs {
+		diffs = append(diffs, DiffNetworkConfigs(previous, c.NetworkConfig))
+		previous = c.NetworkConfig
+	}
+
+	return diffs
+}
+
+// AddTransaction adds change scheduled by confirmed NetworkConfigTransaction or BlockchainUpgradeTransaction.
+// Such change takes effect at height of transaction plus its delta, so the timeline can contain future changes.
+// Other transactions are ignored
+func (t *NetworkTimeline) AddTransaction(tx Transaction) error {
+	abs := tx.GetAbstractTransaction()
+
+	switch tx := tx.(type) {
+	case *NetworkConfigTransaction:
+		if abs.TransactionInfo.Height == 0 {
+			return ErrNilOrZeroHeight
+		}
+
+		t.addConfig(&BlockchainConfig{
+			StartedHeight:           abs.TransactionInfo.Height + tx.ApplyHeightDelta,
+			NetworkConfig:           tx.NetworkConfig,
+			SupportedEntityVersions: tx.SupportedEntities,
+		})
+	case *BlockchainUpgradeTransaction:
+		if abs.TransactionInfo.Height == 0 {
+			return ErrNilOrZeroHeight
+		}
+
+		t.addVersion(&NetworkVersion{
+			StartedHeight:     abs.TransactionInfo.Height + tx.UpgradePeriod,
+			BlockChainVersion: tx.NewBlockChainVersion,
+		})
+	}
+
+	return nil
+}
+
+// configs with the same height replace each other
+func (t *NetworkTimeline) addConfig(config *BlockchainConfig) {
+	i := sort.Search(len(t.Configs), func(i int) bool {
+		return t.Configs[i].StartedHeight >= config.StartedHeight
+	})
+
+	if i < len(t.Configs) && t.Configs[i].StartedHeight == config.StartedHeight {
+		t.Configs[i] = config
+		return
+	}
+
+	t.Configs = append(t.Configs, nil)
+	copy(t.Configs[i+1:], t.Configs[i:])
+	t.Configs[i] = config
+}
+
+func (t *NetworkTimeline) addVersion(version *NetworkVersion) {
+	i := sort.Search(len(t.Versions), func(i int) bool {
+		return t.Versions[i].StartedHeight >= version.StartedHeight
+	})
+
+	if i < len(t.Versions) && t.Versions[i].StartedHeight == version.StartedHeight {
+		t.Versions[i] = version
+		return
+	}
+
+	t.Versions = append(t.Versions, nil)
+	copy(t.Versions[i+1:], t.Versions[i:])
+	t.Versions[i] = version
+}
+
+// returns timeline of configs and versions active from passed height up to the current one.
+// It goes back from the current height, every request returns height at which the config took effect
+func (ref *NetworkService) GetNetworkTimeline(ctx context.Context, fromHeight Height) (*NetworkTimeline, error) {
+	if fromHeight == 0 {
+		fromHeight = 1
+	}
+
+	height, err := ref.BlockchainService.GetBlockchainHeight(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &NetworkTimeline{
+		Configs:  make([]*BlockchainConfig, 0),
+		Versions: make([]*NetworkVersion, 0),
+	}
+
+	for h := height; h >= fromHeight; {
+		config, err := ref.GetNetworkConfigAtHeight(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+
+		t.addConfig(config)
+
+		if config.StartedHeight <= 1 || config.StartedHeight > h {
+			break
+		}
+		h = config.StartedHeight - 1
+	}
+
+	for h := height; h >= fromHeight; {
+		version, err := ref.GetNetworkVersionAtHeight(ctx, h)
+		if err != nil {
+			return nil, err
+		}
+
+		t.addVersion(version)
+
+		if version.StartedHeight <= 1 || version.StartedHeight > h {
+			break
+		}
+		h = version.StartedHeight - 1
+	}
+
+	return t, nil
+}
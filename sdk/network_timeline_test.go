@@ -0,0 +1,136 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func testConfigJSON(startedHeight uint32, identifier string) string {
+	return fmt.Sprintf(`{
+		"networkConfig": {
+			"height": [%d, 0],
+			"networkConfig": "[network]\nidentifier = %s\n",
+			"supportedEntityVersions": "{\"entities\": [{\"name\": \"Transfer\", \"type\": \"16724\", \"supportedVersions\": [3]}]}"
+		}
+	}`, startedHeight, identifier)
+}
+
+func testVersionJSON(startedHeight uint32, major uint32) string {
+	return fmt.Sprintf(`{"blockchainUpgrade": {"height": [%d, 0], "blockChainVersion": [0, %d]}}`, startedHeight, major)
+}
+
+func TestNetworkService_GetNetworkTimeline(t *testing.T) {
+	mockServ := newSdkMockWithRouter(&mock.Router{
+		Path:     blockHeightRoute,
+		RespBody: `{"height":[300,0]}`,
+	})
+	defer mockServ.Close()
+
+	mockServ.AddRouter(&mock.Router{Path: fmt.Sprintf(configRoute, Height(300)), RespBody: testConfigJSON(200, "second")})
+	mockServ.AddRouter(&mock.Router{Path: fmt.Sprintf(configRoute, Height(199)), RespBody: testConfigJSON(1, "first")})
+	mockServ.AddRouter(&mock.Router{Path: fmt.Sprintf(upgradeRoute, Height(300)), RespBody: testVersionJSON(250, 5)})
+	mockServ.AddRouter(&mock.Router{Path: fmt.Sprintf(upgradeRoute, Height(249)), RespBody: testVersionJSON(1, 4)})
+
+	timeline, err := mockServ.getPublicTestClientUnsafe().Network.GetNetworkTimeline(ctx, 1)
+	assert.Nil(t, err)
+
+	assert.Len(t, timeline.Configs, 2)
+	assert.Equal(t, Height(1), timeline.Configs[0].StartedHeight)
+	assert.Equal(t, Height(200), timeline.Configs[1].StartedHeight)
+
+	v, err := timeline.ConfigAt(199).NetworkConfig.Get(NetworkSection, "identifier")
+	assert.Nil(t, err)
+	assert.Equal(t, "first", v)
+
+	v, err = timeline.ConfigAt(200).NetworkConfig.Get(NetworkSection, "identifier")
+	assert.Nil(t, err)
+	assert.Equal(t, "second", v)
+
+	assert.Len(t, timeline.Versions, 2)
+	assert.Equal(t, NewBlockChainVersion(0, 4, 0, 0), timeline.VersionAt(249).BlockChainVersion)
+	assert.Equal(t, NewBlockChainVersion(0, 5, 0, 0), timeline.VersionAt(250).BlockChainVersion)
+	assert.Nil(t, timeline.VersionAt(0))
+
+	changes := timeline.ConfigChanges()
+	assert.Len(t, changes, 2)
+	assert.Equal(t, ChangeModified, changes[1].Fields[0].Kind)
+
+	// only configs from height 250 are requested
+	timeline, err = mockServ.getPublicTestClientUnsafe().Network.GetNetworkTimeline(ctx, 250)
+	assert.Nil(t, err)
+	assert.Len(t, timeline.Configs, 1)
+	assert.Len(t, timeline.Versions, 1)
+}
+
+func TestNetworkTimeline_AddTransaction(t *testing.T) {
+	timeline := &NetworkTimeline{}
+
+	configTx, err := NewNetworkConfigTransaction(fakeDeadline, 100, NewNetworkConfig(), NewSupportedEntities(), MijinTest)
+	assert.Nil(t, err)
+	configTx.TransactionInfo.Height = 50
+
+	upgradeTx, err := NewBlockchainUpgradeTransaction(fakeDeadline, 20, NewBlockChainVersion(0, 5, 0, 0), MijinTest)
+	assert.Nil(t, err)
+	upgradeTx.TransactionInfo.Height = 10
+
+	assert.Nil(t, timeline.AddTransaction(configTx))
+	assert.Nil(t, timeline.AddTransaction(upgradeTx))
+	assert.Nil(t, timeline.AddTransaction(&TransferTransaction{}))
+
+	timeline.addConfig(&BlockchainConfig{StartedHeight: 1})
+
+	assert.Equal(t, Height(1), timeline.ConfigAt(149).StartedHeight)
+	assert.Equal(t, configTx.NetworkConfig, timeline.ConfigAt(150).NetworkConfig)
+	assert.Equal(t, Height(30), timeline.VersionAt(30).StartedHeight)
+
+	configTx.TransactionInfo.Height = 0
+	assert.Equal(t, ErrNilOrZeroHeight, timeline.AddTransaction(configTx))
+}
+
+func TestClient_SetSupportedEntities(t *testing.T) {
+	client := mockServer.getPublicTestClientUnsafe()
+	defer client.SetSupportedEntities(nil)
+
+	transferVersions := serializableEntityVersions[Transfer]
+	defer func() { serializableEntityVersions[Transfer] = transferVersions }()
+	serializableEntityVersions[Transfer] = []EntityVersion{TransferVersion - 1, TransferVersion}
+
+	newTransfer := func() *TransferTransaction {
+		tx, err := client.NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{}, NewPlainMessage(""))
+		assert.Nil(t, err)
+		return tx
+	}
+
+	assert.Equal(t, TransferVersion, newTransfer().Version)
+
+	entities := NewSupportedEntities()
+	entities.Entities[Transfer] = &Entity{Name: "Transfer", Type: Transfer, SupportedVersions: []EntityVersion{TransferVersion - 1, TransferVersion, TransferVersion + 1}}
+	client.SetSupportedEntities(entities)
+	assert.Equal(t, TransferVersion, newTransfer().Version)
+
+	// network is not upgraded yet, so the previous version is picked
+	entities.Entities[Transfer].SupportedVersions = []EntityVersion{TransferVersion - 1}
+	assert.Equal(t, TransferVersion-1, newTransfer().Version)
+
+	// no version of the SDK is supported after upgrade
+	entities.Entities[Transfer].SupportedVersions = []EntityVersion{TransferVersion + 1, TransferVersion + 2}
+	tx, err := client.NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{}, NewPlainMessage(""))
+	assert.Nil(t, tx)
+	assert.Equal(t, ErrEntityVersionUnsupported, errors.Cause(err))
+
+	delete(entities.Entities, Transfer)
+	tx, err = client.NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{}, NewPlainMessage(""))
+	assert.Nil(t, tx)
+	assert.Equal(t, ErrEntityVersionUnsupported, errors.Cause(err))
+
+	client.SetSupportedEntities(nil)
+	assert.Equal(t, TransferVersion, newTransfer().Version)
+}
@@ -8,12 +8,13 @@ import (
 	"fmt"
 )
 
-type nodeRole uint8
+// NodeRole is a bit flag of NodeInfo.Roles
+type NodeRole uint8
 
 const (
-	None nodeRole = 0x00
-	Peer nodeRole = 0x01
-	Api  nodeRole = 0x02
+	None NodeRole = 0x00
+	Peer NodeRole = 0x01
+	Api  NodeRole = 0x02
 )
 
 type NodeInfo struct {
@@ -25,11 +26,13 @@ type NodeInfo struct {
 	Roles        int
 }
 
-func (n *NodeInfo) isApiNode() bool {
+// returns true when node serves REST API
+func (n *NodeInfo) IsApiNode() bool {
 	return n.Roles&int(Api) > 0
 }
 
-func (n *NodeInfo) isPeerNode() bool {
+// returns true when node takes part in p2p network
+func (n *NodeInfo) IsPeerNode() bool {
 	return n.Roles&int(Peer) > 0
 }
 
@@ -0,0 +1,397 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultLagThreshold Height = 5
+
+// nodeChainSource is the part of BlockchainService used by NodeMonitor
+type nodeChainSource interface {
+	GetBlockchainHeight(ctx context.Context) (Height, error)
+	GetBlockByHeight(ctx context.Context, height Height) (*BlockInfo, error)
+	GetBlockchainScore(ctx context.Context) (*ChainScore, error)
+}
+
+type monitoredNode struct {
+	url    string
+	source nodeChainSource
+}
+
+type NodeMonitorConfig struct {
+	// Node is lagging when it is behind the highest node by more than LagThreshold blocks. Zero means 5
+	LagThreshold Height
+}
+
+// NodeMonitor compares chains of all nodes from Config.BaseURLs with the best chain to find divergent and lagging nodes
+type NodeMonitor struct {
+	nodes  []*monitoredNode
+	config NodeMonitorConfig
+}
+
+// NodeStatus is the state of one node at the moment of check
+type NodeStatus struct {
+	URL    string
+	Height Height
+	Score  *ChainScore
+	// Lower of heights of node and reference node, blocks of both nodes at this height are compared
+	ComparedHeight Height
+	// Hash of node's block at ComparedHeight
+	BlockHash *Hash
+	// Number of blocks node is behind the highest node
+	Lag       Height
+	Lagging   bool
+	Divergent bool
+	// First height where node's chain differs from the chain of reference node. Zero if node is not divergent
+	ForkHeight Height
+	// Hash of node's block at ForkHeight
+	ForkBlockHash *Hash
+	// Node is excluded from comparison when it can't be requested
+	Err error
+}
+
+func (s *NodeStatus) reachable() bool {
+	return s.Err == nil
+}
+
+// ForkPoint groups divergent nodes following the same fork
+type ForkPoint struct {
+	Height Height
+	// Hash of the first block of fork
+	BlockHash *Hash
+	URLs      []string
+}
+
+// ConsistencyReport is the result of NodeMonitor check
+type ConsistencyReport struct {
+	CheckedAt time.Time
+	MaxHeight Height
+	// Highest height which is known by all reachable nodes
+	CommonHeight Height
+	// URL of node with the best chain which other nodes are compared with
+	Reference string
+	Nodes     []*NodeStatus
+	Forks     []*ForkPoint
+}
+
+// returns true when all nodes are reachable, follow the same chain and no one is lagging
+func (r *ConsistencyReport) Healthy() bool {
+	for _, n := range r.Nodes {
+		if !n.reachable() || n.Lagging || n.Divergent {
+			return false
+		}
+	}
+
+	return true
+}
+
+// returns nodes whose chains differ from the chain of reference node
+func (r *ConsistencyReport) DivergentNodes() []*NodeStatus {
+	return r.filter(func(n *NodeStatus) bool { return n.Divergent })
+}
+
+// returns nodes which are behind the highest node by more than lag threshold
+func (r *ConsistencyReport) LaggingNodes() []*NodeStatus {
+	return r.filter(func(n *NodeStatus) bool { return n.Lagging })
+}
+
+func (r *ConsistencyReport) filter(fn func(n *NodeStatus) bool) []*NodeStatus {
+	nodes := make([]*NodeStatus, 0)
+	for _, n := range r.Nodes {
+		if fn(n) {
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// returns report as JSON status document
+func (r *ConsistencyReport) MarshalJSON() ([]byte, error) {
+	return json.Marshal(consistencyReportDTO{}.fromStruct(r))
+}
+
+// returns monitor of all nodes from client's config. Every node is requested directly without failover
+func (c *Client) NewNodeMonitor(cfg NodeMonitorConfig) *NodeMonitor {
+	nodes := make([]*monitoredNode, 0, len(c.config.BaseURLs))
+	for _, u := range c.config.BaseURLs {
+		nodes = append(nodes, &monitoredNode{
+			url:    u.String(),
+			source: c.nodeClient(u).Blockchain,
+		})
+	}
+
+	return newNodeMonitor(nodes, cfg)
+}
+
+func newNodeMonitor(nodes []*monitoredNode, cfg NodeMonitorConfig) *NodeMonitor {
+	if cfg.LagThreshold == 0 {
+		cfg.LagThreshold = defaultLagThreshold
+	}
+
+	return &NodeMonitor{nodes: nodes, config: cfg}
+}
+
+// Check requests all nodes and compares their chains.
+// Node with the highest chain score is the reference, as the network itself switches to the chain with the highest score.
+// Every other node is compared with the reference at the lower of their heights, so a lagging node doesn't hide forks
+// of other nodes. Fork height of every divergent node is found by binary search against the reference node
+func (m *NodeMonitor) Check(ctx context.Context) (*ConsistencyReport, error) {
+	report := &ConsistencyReport{
+		CheckedAt: time.Now(),
+		Nodes:     make([]*NodeStatus, len(m.nodes)),
+		Forks:     make([]*ForkPoint, 0),
+	}
+
+	m.forEachNode(func(i int, node *monitoredNode) {
+		report.Nodes[i] = m.nodeStatus(ctx, node)
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, n := range report.Nodes {
+		if !n.reachable() {
+			continue
+		}
+
+		if n.Height > report.MaxHeight {
+			report.MaxHeight = n.Height
+		}
+
+		if report.CommonHeight == 0 || n.Height < report.CommonHeight {
+			report.CommonHeight = n.Height
+		}
+	}
+
+	if report.CommonHeight == 0 {
+		return report, nil
+	}
+
+	for _, n := range report.Nodes {
+		if n.reachable() {
+			n.Lag = report.MaxHeight - n.Height
+			n.Lagging = n.Lag > m.config.LagThreshold
+		}
+	}
+
+	reference := report.reference()
+	report.Reference = report.Nodes[reference].URL
+
+	m.forEachNode(func(i int, node *monitoredNode) {
+		status := report.Nodes[i]
+		if i == reference || !status.reachable() {
+			return
+		}
+
+		m.compare(ctx, m.nodes[reference], report.Nodes[reference], node, status)
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	report.groupForks()
+
+	return report, nil
+}
+
+// Monitor checks nodes every interval and sends reports until ctx is done.
+// Reports are dropped when the caller doesn't read them in time
+func (m *NodeMonitor) Monitor(ctx context.Context, interval time.Duration) <-chan *ConsistencyReport {
+	reportsCh := make(chan *ConsistencyReport, 1)
+
+	go func() {
+		defer close(reportsCh)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			if report, err := m.Check(ctx); err == nil {
+				select {
+				case reportsCh <- report:
+				default:
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return reportsCh
+}
+
+func (m *NodeMonitor) forEachNode(fn func(i int, node *monitoredNode)) {
+	var wg sync.WaitGroup
+	for i, node := range m.nodes {
+		wg.Add(1)
+		go func(i int, node *monitoredNode) {
+			defer wg.Done()
+			fn(i, node)
+		}(i, node)
+	}
+	wg.Wait()
+}
+
+func (m *NodeMonitor) nodeStatus(ctx context.Context, node *monitoredNode) *NodeStatus {
+	status := &NodeStatus{URL: node.url}
+
+	status.Height, status.Err = node.source.GetBlockchainHeight(ctx)
+	if status.Err != nil {
+		return status
+	}
+
+	status.Score, status.Err = node.source.GetBlockchainScore(ctx)
+	return status
+}
+
+// compares node with reference node at the lower of their heights and searches fork height when blocks differ
+func (m *NodeMonitor) compare(ctx context.Context, reference *monitoredNode, referenceStatus *NodeStatus, node *monitoredNode, status *NodeStatus) {
+	status.ComparedHeight = status.Height
+	if referenceStatus.Height < status.ComparedHeight {
+		status.ComparedHeight = referenceStatus.Height
+	}
+
+	if status.ComparedHeight == 0 {
+		return
+	}
+
+	a, err := reference.source.GetBlockByHeight(ctx, status.ComparedHeight)
+	if err != nil {
+		status.Err = err
+		return
+	}
+
+	b, err := node.source.GetBlockByHeight(ctx, status.ComparedHeight)
+	if err != nil {
+		status.Err = err
+		return
+	}
+
+	status.BlockHash = b.BlockHash
+	if sameBlock(a, b) {
+		return
+	}
+
+	status.Divergent = true
+	status.ForkHeight, status.ForkBlockHash, err = m.forkHeight(ctx, reference, node, status.ComparedHeight, b.BlockHash)
+	if err != nil {
+		status.Err = err
+	}
+}
+
+// returns the first height where chains of nodes differ and hash of divergent block at this height.
+// Blocks at divergentHeight are known to differ, divergentHash is hash of divergent block at this height
+func (m *NodeMonitor) forkHeight(ctx context.Context, reference, divergent *monitoredNode, divergentHeight Height, divergentHash *Hash) (Height, *Hash, error) {
+	low, high := Height(0), divergentHeight
+
+	for high-low > 1 {
+		mid := low + (high-low)/2
+
+		a, err := reference.source.GetBlockByHeight(ctx, mid)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		b, err := divergent.source.GetBlockByHeight(ctx, mid)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if sameBlock(a, b) {
+			low = mid
+		} else {
+			high, divergentHash = mid, b.BlockHash
+		}
+	}
+
+	return high, divergentHash, nil
+}
+
+// returns index of reachable node with the highest score, on tie the highest node
+func (r *ConsistencyReport) reference() int {
+	reference := -1
+	for i, n := range r.Nodes {
+		if !n.reachable() {
+			continue
+		}
+
+		if reference == -1 {
+			reference = i
+			continue
+		}
+
+		best := r.Nodes[reference]
+		if c := compareChainScores(n.Score, best.Score); c > 0 || (c == 0 && n.Height > best.Height) {
+			reference = i
+		}
+	}
+
+	return reference
+}
+
+// groups divergent nodes by the first block of their forks
+func (r *ConsistencyReport) groupForks() {
+	type forkKey struct {
+		height Height
+		hash   Hash
+	}
+
+	forks := make(map[forkKey]*ForkPoint)
+	for _, n := range r.Nodes {
+		if !n.Divergent || n.ForkHeight == 0 || n.ForkBlockHash == nil {
+			continue
+		}
+
+		key := forkKey{n.ForkHeight, *n.ForkBlockHash}
+		fork, ok := forks[key]
+		if !ok {
+			hash := key.hash
+			fork = &ForkPoint{Height: n.ForkHeight, BlockHash: &hash}
+			forks[key] = fork
+			r.Forks = append(r.Forks, fork)
+		}
+
+		fork.URLs = append(fork.URLs, n.URL)
+	}
+}
+
+func sameBlock(a, b *BlockInfo) bool {
+	return a.BlockHash != nil && b.BlockHash != nil && a.BlockHash.Equal(b.BlockHash)
+}
+
+// returns positive value when a is greater than b. Nil score is the lowest
+func compareChainScores(a, b *ChainScore) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case b == nil:
+		return 1
+	case a == nil:
+		return -1
+	}
+
+	// ChainScore keeps low part first
+	for i := 1; i >= 0; i-- {
+		if a[i] != b[i] {
+			if a[i] > b[i] {
+				return 1
+			}
+			return -1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"time"
+)
+
+type nodeStatusDTO struct {
+	URL            string `json:"url"`
+	Height         uint64 `json:"height"`
+	Score          string `json:"score,omitempty"`
+	ComparedHeight uint64 `json:"comparedHeight,omitempty"`
+	BlockHash      string `json:"blockHash,omitempty"`
+	Lag            uint64 `json:"lag"`
+	Lagging        bool   `json:"lagging"`
+	Divergent      bool   `json:"divergent"`
+	ForkHeight     uint64 `json:"forkHeight,omitempty"`
+	ForkBlockHash  string `json:"forkBlockHash,omitempty"`
+	Error          string `json:"error,omitempty"`
+}
+
+func (nodeStatusDTO) fromStruct(s *NodeStatus) *nodeStatusDTO {
+	dto := &nodeStatusDTO{
+		URL:            s.URL,
+		Height:         uint64(s.Height),
+		ComparedHeight: uint64(s.ComparedHeight),
+		Lag:            uint64(s.Lag),
+		Lagging:        s.Lagging,
+		Divergent:      s.Divergent,
+		ForkHeight:     uint64(s.ForkHeight),
+	}
+
+	if s.Score != nil {
+		dto.Score = s.Score.String()
+	}
+
+	if s.BlockHash != nil {
+		dto.BlockHash = s.BlockHash.String()
+	}
+
+	if s.ForkBlockHash != nil {
+		dto.ForkBlockHash = s.ForkBlockHash.String()
+	}
+
+	if s.Err != nil {
+		dto.Error = s.Err.Error()
+	}
+
+	return dto
+}
+
+type forkPointDTO struct {
+	Height    uint64   `json:"height"`
+	BlockHash string   `json:"blockHash"`
+	URLs      []string `json:"urls"`
+}
+
+type consistencyReportDTO struct {
+	CheckedAt    time.Time        `json:"checkedAt"`
+	Healthy      bool             `json:"healthy"`
+	MaxHeight    uint64           `json:"maxHeight"`
+	CommonHeight uint64           `json:"commonHeight"`
+	Reference    string           `json:"reference,omitempty"`
+	Nodes        []*nodeStatusDTO `json:"nodes"`
+	Forks        []*forkPointDTO  `json:"forks"`
+}
+
+func (consistencyReportDTO) fromStruct(r *ConsistencyReport) *consistencyReportDTO {
+	dto := &consistencyReportDTO{
+		CheckedAt:    r.CheckedAt,
+		Healthy:      r.Healthy(),
+		MaxHeight:    uint64(r.MaxHeight),
+		CommonHeight: uint64(r.CommonHeight),
+		Reference:    r.Reference,
+		Nodes:        make([]*nodeStatusDTO, 0, len(r.Nodes)),
+		Forks:        make([]*forkPointDTO, 0, len(r.Forks)),
+	}
+
+	for _, n := range r.Nodes {
+		dto.Nodes = append(dto.Nodes, nodeStatusDTO{}.fromStruct(n))
+	}
+
+	for _, f := range r.Forks {
+		fork := &forkPointDTO{Height: uint64(f.Height), URLs: f.URLs}
+		if f.BlockHash != nil {
+			fork.BlockHash = f.BlockHash.String()
+		}
+		dto.Forks = append(dto.Forks, fork)
+	}
+
+	return dto
+}
@@ -0,0 +1,136 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeNodeChain has blocks with hash {height, fork} where fork is set from forkHeight
+type fakeNodeChain struct {
+	height     Height
+	forkHeight Height
+	fork       byte
+	score      uint64
+	err        error
+}
+
+func (c *fakeNodeChain) GetBlockchainHeight(context.Context) (Height, error) {
+	return c.height, c.err
+}
+
+func (c *fakeNodeChain) GetBlockByHeight(_ context.Context, height Height) (*BlockInfo, error) {
+	hash := &Hash{byte(height)}
+	if c.forkHeight != 0 && height >= c.forkHeight {
+		hash[1] = c.fork
+	}
+
+	return &BlockInfo{Height: height, BlockHash: hash}, nil
+}
+
+func (c *fakeNodeChain) GetBlockchainScore(context.Context) (*ChainScore, error) {
+	return NewChainScore(c.score, 0), nil
+}
+
+func TestNodeMonitor_Check(t *testing.T) {
+	unreachable := errors.New("connection refused")
+
+	m := newNodeMonitor([]*monitoredNode{
+		{url: "http://a", source: &fakeNodeChain{height: 100, score: 10}},
+		{url: "http://b", source: &fakeNodeChain{height: 102, score: 11}},
+		{url: "http://c", source: &fakeNodeChain{height: 101, forkHeight: 37, fork: 1, score: 9}},
+		{url: "http://d", source: &fakeNodeChain{height: 90, score: 8}},
+		{url: "http://e", source: &fakeNodeChain{err: unreachable}},
+	}, NodeMonitorConfig{})
+
+	report, err := m.Check(context.Background())
+	assert.Nil(t, err)
+
+	assert.Equal(t, Height(102), report.MaxHeight)
+	assert.Equal(t, Height(90), report.CommonHeight)
+	assert.False(t, report.Healthy())
+
+	assert.Len(t, report.DivergentNodes(), 1)
+	assert.Equal(t, "http://c", report.DivergentNodes()[0].URL)
+	assert.Equal(t, Height(37), report.Nodes[2].ForkHeight)
+
+	assert.Len(t, report.LaggingNodes(), 1)
+	assert.Equal(t, "http://d", report.LaggingNodes()[0].URL)
+	assert.Equal(t, Height(12), report.Nodes[3].Lag)
+
+	assert.Equal(t, unreachable, report.Nodes[4].Err)
+
+	assert.Equal(t, "http://b", report.Reference)
+	assert.Equal(t, Height(90), report.Nodes[3].ComparedHeight)
+
+	assert.Len(t, report.Forks, 1)
+	assert.Equal(t, Height(37), report.Forks[0].Height)
+	assert.Equal(t, &Hash{37, 1}, report.Forks[0].BlockHash)
+	assert.Equal(t, []string{"http://c"}, report.Forks[0].URLs)
+
+	b, err := json.Marshal(report)
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), `"url":"http://c","height":101,"score":"[ 9, 0 ]"`)
+	assert.Contains(t, string(b), `"divergent":true,"forkHeight":37`)
+	assert.Contains(t, string(b), `"error":"connection refused"`)
+	assert.Contains(t, string(b), `"forks":[{"height":37`)
+}
+
+func TestNodeMonitor_Check_TieBrokenByScore(t *testing.T) {
+	m := newNodeMonitor([]*monitoredNode{
+		{url: "http://a", source: &fakeNodeChain{height: 50, forkHeight: 1, fork: 1, score: 5}},
+		{url: "http://b", source: &fakeNodeChain{height: 50, score: 6}},
+	}, NodeMonitorConfig{})
+
+	report, err := m.Check(context.Background())
+	assert.Nil(t, err)
+
+	assert.True(t, report.Nodes[0].Divergent)
+	assert.False(t, report.Nodes[1].Divergent)
+	assert.Equal(t, Height(1), report.Nodes[0].ForkHeight)
+}
+
+func TestNodeMonitor_Check_LaggingNodeDoesNotHideForks(t *testing.T) {
+	m := newNodeMonitor([]*monitoredNode{
+		{url: "http://a", source: &fakeNodeChain{height: 100, score: 10}},
+		{url: "http://b", source: &fakeNodeChain{height: 30, score: 1}},
+		{url: "http://c", source: &fakeNodeChain{height: 100, forkHeight: 50, fork: 1, score: 9}},
+		{url: "http://d", source: &fakeNodeChain{height: 80, forkHeight: 50, fork: 1, score: 8}},
+		{url: "http://e", source: &fakeNodeChain{height: 99, forkHeight: 60, fork: 2, score: 9}},
+	}, NodeMonitorConfig{})
+
+	report, err := m.Check(context.Background())
+	assert.Nil(t, err)
+
+	assert.Equal(t, Height(30), report.CommonHeight)
+	assert.Equal(t, "http://a", report.Reference)
+	assert.False(t, report.Nodes[1].Divergent)
+	assert.True(t, report.Nodes[1].Lagging)
+
+	assert.Equal(t, Height(50), report.Nodes[2].ForkHeight)
+	assert.Equal(t, Height(80), report.Nodes[3].ComparedHeight)
+	assert.Equal(t, Height(50), report.Nodes[3].ForkHeight)
+	assert.Equal(t, Height(60), report.Nodes[4].ForkHeight)
+
+	assert.Len(t, report.Forks, 2)
+	assert.Equal(t, &ForkPoint{Height: 50, BlockHash: &Hash{50, 1}, URLs: []string{"http://c", "http://d"}}, report.Forks[0])
+	assert.Equal(t, &ForkPoint{Height: 60, BlockHash: &Hash{60, 2}, URLs: []string{"http://e"}}, report.Forks[1])
+}
+
+func TestNodeMonitor_Check_Healthy(t *testing.T) {
+	m := newNodeMonitor([]*monitoredNode{
+		{url: "http://a", source: &fakeNodeChain{height: 50}},
+		{url: "http://b", source: &fakeNodeChain{height: 48}},
+	}, NodeMonitorConfig{LagThreshold: 2})
+
+	report, err := m.Check(context.Background())
+	assert.Nil(t, err)
+	assert.True(t, report.Healthy())
+	assert.Empty(t, report.Forks)
+}
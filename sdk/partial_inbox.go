@@ -0,0 +1,452 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const partialInboxPageSize = 100
+
+// PartialInboxSource is the part of AccountService and LockService used by PartialInbox
+type PartialInboxSource interface {
+	AggregateBondedTransactions(ctx context.Context, account *PublicAccount, opt *AccountTransactionsOption) ([]*AggregateTransaction, error)
+	GetMultisigAccountGraphInfo(ctx context.Context, address *Address) (*MultisigAccountGraphInfo, error)
+	GetHashLockInfo(ctx context.Context, hash *Hash) (*HashLockInfo, error)
+}
+
+type clientPartialInboxSource struct {
+	*AccountService
+	*LockService
+}
+
+// InnerSignerStatus describes approval of transactions signed by one inner signer of partial aggregate
+type InnerSignerStatus struct {
+	Account *PublicAccount
+	// Cosignatories of the account, including nested ones, which have signed
+	Signed []*PublicAccount
+	// Cosignatories of the account, including nested ones, which haven't signed yet
+	Missing []*PublicAccount
+	// Minimal sets of signers which are still missing, smaller sets first. Nil when approved
+	MissingSets [][]*PublicAccount
+	// True when MinApproval or MinRemoval of every multisig level is met
+	Approved bool
+	// True when threshold is met or can be met by missing cosignatories
+	Reachable bool
+}
+
+// PartialStatus describes what partial aggregate is still waiting for
+type PartialStatus struct {
+	Aggregate *AggregateTransaction
+	Hash      *Hash
+	// Signer of aggregate and its cosignatories
+	Signed []*PublicAccount
+	// One status per inner signer in order of the first inner transaction of the signer
+	InnerSigners []*InnerSignerStatus
+	// True when every inner signer is approved, so aggregate is going to be confirmed
+	Complete bool
+	// Height at which hash lock of aggregate expires or zero when lock isn't found
+	LockExpiryHeight Height
+	// Estimated time of LockExpiryHeight or zero time when it can't be estimated
+	LockExpiry time.Time
+}
+
+// PartialInbox tracks partial aggregates of account and what every of them is still waiting for.
+// It is filled by Refresh and kept updated by AddPartial, AddCosignature and RemovePartial,
+// e.g. from partialAdded, cosignature and partialRemoved websocket topics.
+// Updates made while Refresh is running are applied on top of its result
+type PartialInbox struct {
+	sync.RWMutex
+	Account  *PublicAccount
+	source   PartialInboxSource
+	clock    *ChainClock
+	partials map[Hash]*PartialStatus
+	// nil planner means inner signer isn't multisig
+	planners map[string]*MultisigSigningPlanner
+	// cosignatures of partials which aren't tracked yet
+	orphans map[Hash][]*AggregateTransactionCosignature
+	// updates made during Refresh, nil when Refresh isn't running
+	journal     []*partialInboxUpdate
+	refreshLock sync.Mutex
+}
+
+// one of AddPartial, AddCosignature or RemovePartial made during Refresh
+type partialInboxUpdate struct {
+	added       *PartialStatus
+	parentHash  *Hash
+	cosignature *AggregateTransactionCosignature
+	removed     *Hash
+}
+
+// returns empty inbox of passed account. Clock is used to estimate lock expiry time and can be nil
+func NewPartialInbox(account *PublicAccount, source PartialInboxSource, clock *ChainClock) *PartialInbox {
+	return &PartialInbox{
+		Account:  account,
+		source:   source,
+		clock:    clock,
+		partials: make(map[Hash]*PartialStatus),
+		planners: make(map[string]*MultisigSigningPlanner),
+		orphans:  make(map[Hash][]*AggregateTransactionCosignature),
+	}
+}
+
+// returns empty inbox of passed account, call Refresh to fill it
+func (c *Client) NewPartialInbox(ctx context.Context, account *PublicAccount) (*PartialInbox, error) {
+	if account == nil {
+		return nil, ErrNilAccount
+	}
+
+	clock, err := c.NewChainClock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPartialInbox(account, &clientPartialInboxSource{c.Account, c.Lock}, clock), nil
+}
+
+// Refresh replaces tracked partials with all partial aggregates of account from REST.
+// Partials added, cosigned or removed while it is running are updated after that
+func (i *PartialInbox) Refresh(ctx context.Context) error {
+	i.refreshLock.Lock()
+	defer i.refreshLock.Unlock()
+
+	i.Lock()
+	i.journal = make([]*partialInboxUpdate, 0)
+	i.Unlock()
+
+	partials, err := i.fetchPartials(ctx)
+
+	i.Lock()
+	defer i.Unlock()
+
+	journal := i.journal
+	i.journal = nil
+
+	if err != nil {
+		return err
+	}
+
+	i.partials = make(map[Hash]*PartialStatus, len(partials))
+	for _, status := range partials {
+		i.put(status)
+	}
+
+	for _, u := range journal {
+		switch {
+		case u.added != nil:
+			i.put(u.added)
+		case u.cosignature != nil:
+			if status, ok := i.partials[*u.parentHash]; ok {
+				i.partials[*u.parentHash] = i.cosign(status, u.cosignature)
+			}
+		case u.removed != nil:
+			delete(i.partials, *u.removed)
+			delete(i.orphans, *u.removed)
+		}
+	}
+
+	return nil
+}
+
+func (i *PartialInbox) fetchPartials(ctx context.Context) ([]*PartialStatus, error) {
+	partials := make([]*PartialStatus, 0)
+
+	opt := &AccountTransactionsOption{PageSize: partialInboxPageSize}
+	for {
+		page, err := i.source.AggregateBondedTransactions(ctx, i.Account, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range page {
+			status, err := i.status(ctx, tx)
+			if err != nil {
+				return nil, err
+			}
+
+			partials = append(partials, status)
+		}
+
+		if len(page) < partialInboxPageSize {
+			return partials, nil
+		}
+		opt = &AccountTransactionsOption{PageSize: partialInboxPageSize, Id: page[len(page)-1].TransactionInfo.Id}
+	}
+}
+
+// AddPartial starts tracking of partial aggregate or updates already tracked one
+func (i *PartialInbox) AddPartial(ctx context.Context, tx *AggregateTransaction) (*PartialStatus, error) {
+	status, err := i.status(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	status = i.put(status)
+	if i.journal != nil {
+		i.journal = append(i.journal, &partialInboxUpdate{added: status})
+	}
+
+	return status, nil
+}
+
+// AddCosignature adds cosignature to tracked partial aggregate.
+// It returns updated status or nil when partial isn't tracked, then cosignature is applied when partial is added
+func (i *PartialInbox) AddCosignature(info *SignerInfo) (*PartialStatus, error) {
+	if info == nil || info.ParentHash == nil {
+		return nil, ErrNilHash
+	}
+
+	signer, err := NewAccountFromPublicKey(info.Signer, i.Account.Address.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := ""
+	if info.Signature != nil {
+		signature = info.Signature.String()
+	}
+
+	cosignature := &AggregateTransactionCosignature{
+		Signature: signature,
+		Signer:    signer,
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	if i.journal != nil {
+		i.journal = append(i.journal, &partialInboxUpdate{parentHash: info.ParentHash, cosignature: cosignature})
+	}
+
+	status, ok := i.partials[*info.ParentHash]
+	if !ok {
+		i.orphans[*info.ParentHash] = append(i.orphans[*info.ParentHash], cosignature)
+		return nil, nil
+	}
+
+	status = i.cosign(status, cosignature)
+	i.partials[*info.ParentHash] = status
+
+	return status, nil
+}
+
+// RemovePartial stops tracking of partial aggregate, e.g. when it is confirmed or expired
+func (i *PartialInbox) RemovePartial(hash *Hash) {
+	if hash == nil {
+		return
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	delete(i.partials, *hash)
+	delete(i.orphans, *hash)
+	if i.journal != nil {
+		i.journal = append(i.journal, &partialInboxUpdate{removed: hash})
+	}
+}
+
+// should be called under lock. Tracks status with cosignatures received before it and returns the tracked status
+func (i *PartialInbox) put(status *PartialStatus) *PartialStatus {
+	for _, c := range i.orphans[*status.Hash] {
+		status = i.cosign(status, c)
+	}
+	delete(i.orphans, *status.Hash)
+
+	i.partials[*status.Hash] = status
+
+	return status
+}
+
+// should be called under lock. Returns copy of status with cosignature or status itself when signer has already signed
+func (i *PartialInbox) cosign(status *PartialStatus, cosignature *AggregateTransactionCosignature) *PartialStatus {
+	for _, s := range status.Signed {
+		if s.PublicKey == cosignature.Signer.PublicKey {
+			return status
+		}
+	}
+
+	aggregate := *status.Aggregate
+	aggregate.Cosignatures = append(append([]*AggregateTransactionCosignature{}, aggregate.Cosignatures...), cosignature)
+
+	updated := *status
+	updated.Aggregate = &aggregate
+	updated.Signed = append(append([]*PublicAccount{}, status.Signed...), cosignature.Signer)
+	i.updateSigners(&updated)
+
+	return &updated
+}
+
+// returns status of tracked partial aggregate or nil
+func (i *PartialInbox) Partial(hash *Hash) *PartialStatus {
+	if hash == nil {
+		return nil
+	}
+
+	i.RLock()
+	defer i.RUnlock()
+
+	return i.partials[*hash]
+}
+
+// returns statuses of every tracked partial aggregate ordered by lock expiry, sooner first
+func (i *PartialInbox) Partials() []*PartialStatus {
+	i.RLock()
+	defer i.RUnlock()
+
+	partials := make([]*PartialStatus, 0, len(i.partials))
+	for _, status := range i.partials {
+		partials = append(partials, status)
+	}
+
+	sort.Slice(partials, func(a, b int) bool {
+		if partials[a].LockExpiryHeight != partials[b].LockExpiryHeight {
+			return partials[a].LockExpiryHeight < partials[b].LockExpiryHeight
+		}
+		return partials[a].Hash.String() < partials[b].Hash.String()
+	})
+
+	return partials
+}
+
+func (i *PartialInbox) status(ctx context.Context, tx *AggregateTransaction) (*PartialStatus, error) {
+	hash := tx.TransactionInfo.TransactionHash
+	if hash == nil {
+		hash = tx.TransactionInfo.AggregateHash
+	}
+
+	if hash == nil {
+		return nil, ErrNilHash
+	}
+
+	status := &PartialStatus{
+		Aggregate: tx,
+		Hash:      hash,
+		Signed:    make([]*PublicAccount, 0, len(tx.Cosignatures)+1),
+	}
+
+	if tx.Signer != nil {
+		status.Signed = append(status.Signed, tx.Signer)
+	}
+
+	for _, c := range tx.Cosignatures {
+		if c.Signer != nil {
+			status.Signed = append(status.Signed, c.Signer)
+		}
+	}
+
+	for _, inner := range tx.InnerTransactions {
+		if err := i.loadPlanner(ctx, inner.GetAbstractTransaction().Signer); err != nil {
+			return nil, err
+		}
+	}
+
+	lock, err := i.source.GetHashLockInfo(ctx, hash)
+	switch {
+	case err == nil:
+		status.LockExpiryHeight = lock.Height
+		if i.clock != nil {
+			status.LockExpiry = i.clock.TimeAt(lock.Height)
+		}
+	case err != ErrResourceNotFound:
+		return nil, err
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.updateSigners(status)
+
+	return status, nil
+}
+
+// requests multisig graph of inner signer once
+func (i *PartialInbox) loadPlanner(ctx context.Context, signer *PublicAccount) error {
+	if signer == nil {
+		return ErrNilAccount
+	}
+
+	i.RLock()
+	_, ok := i.planners[signer.PublicKey]
+	i.RUnlock()
+
+	if ok {
+		return nil
+	}
+
+	var planner *MultisigSigningPlanner
+	graph, err := i.source.GetMultisigAccountGraphInfo(ctx, signer.Address)
+	switch {
+	case err == nil:
+		planner, err = NewMultisigSigningPlanner(signer, graph)
+		if err != nil && err != ErrNotMultisigAccount {
+			return err
+		}
+	case err != ErrResourceNotFound:
+		return err
+	}
+
+	i.Lock()
+	defer i.Unlock()
+
+	i.planners[signer.PublicKey] = planner
+
+	return nil
+}
+
+// should be called under lock after planners of inner signers are loaded
+func (i *PartialInbox) updateSigners(status *PartialStatus) {
+	signed := make(map[string]bool, len(status.Signed))
+	for _, s := range status.Signed {
+		signed[s.PublicKey] = true
+	}
+
+	signers := make([]*PublicAccount, 0)
+	removals := make(map[string]bool)
+	for _, inner := range status.Aggregate.InnerTransactions {
+		signer := inner.GetAbstractTransaction().Signer
+		if _, ok := removals[signer.PublicKey]; !ok {
+			signers = append(signers, signer)
+		}
+		removals[signer.PublicKey] = removals[signer.PublicKey] || IsMultisigRemoval(inner)
+	}
+
+	status.Complete = true
+	status.InnerSigners = make([]*InnerSignerStatus, len(signers))
+	for j, signer := range signers {
+		s := &InnerSignerStatus{
+			Account: signer,
+			Signed:  make([]*PublicAccount, 0),
+			Missing: make([]*PublicAccount, 0),
+		}
+
+		cosignatories := []*PublicAccount{signer}
+		if planner := i.planners[signer.PublicKey]; planner != nil {
+			cosignatories = planner.Signers()
+			s.MissingSets = planner.missingSigners(removals[signer.PublicKey], signed)
+		} else if !signed[signer.PublicKey] {
+			s.MissingSets = [][]*PublicAccount{{signer}}
+		}
+
+		for _, c := range cosignatories {
+			if signed[c.PublicKey] {
+				s.Signed = append(s.Signed, c)
+			} else {
+				s.Missing = append(s.Missing, c)
+			}
+		}
+
+		s.Approved = s.MissingSets == nil
+		s.Reachable = s.Approved || len(s.MissingSets) > 0
+		status.Complete = status.Complete && s.Approved
+		status.InnerSigners[j] = s
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePartialInboxSource struct {
+	partials      []*AggregateTransaction
+	graphs        map[string]*MultisigAccountGraphInfo
+	locks         map[Hash]*HashLockInfo
+	graphRequests int
+	// called before partials are returned, e.g. to imitate websocket updates during Refresh
+	onRequest func()
+}
+
+func (s *fakePartialInboxSource) AggregateBondedTransactions(context.Context, *PublicAccount, *AccountTransactionsOption) ([]*AggregateTransaction, error) {
+	if s.onRequest != nil {
+		s.onRequest()
+	}
+
+	return s.partials, nil
+}
+
+func (s *fakePartialInboxSource) GetMultisigAccountGraphInfo(_ context.Context, address *Address) (*MultisigAccountGraphInfo, error) {
+	s.graphRequests++
+
+	graph, ok := s.graphs[address.Address]
+	if !ok {
+		return nil, ErrResourceNotFound
+	}
+
+	return graph, nil
+}
+
+func (s *fakePartialInboxSource) GetHashLockInfo(_ context.Context, hash *Hash) (*HashLockInfo, error) {
+	lock, ok := s.locks[*hash]
+	if !ok {
+		return nil, ErrResourceNotFound
+	}
+
+	return lock, nil
+}
+
+func testPartial(hash *Hash, signer *PublicAccount, innerSigner *PublicAccount) *AggregateTransaction {
+	inner := &TransferTransaction{}
+	inner.Signer = innerSigner
+
+	tx := &AggregateTransaction{InnerTransactions: []Transaction{inner}}
+	tx.Type = AggregateBonded
+	tx.Signer = signer
+	tx.TransactionInfo.TransactionHash = hash
+
+	return tx
+}
+
+func TestPartialInbox(t *testing.T) {
+	tr := newTestTreasury(t)
+	outsider := testMultisigOutsider(t)
+
+	source := &fakePartialInboxSource{
+		partials: []*AggregateTransaction{testPartial(&Hash{1}, tr.a.PublicAccount, tr.treasury)},
+		graphs:   map[string]*MultisigAccountGraphInfo{tr.treasury.Address.Address: tr.graph},
+		locks:    map[Hash]*HashLockInfo{{1}: {CommonLockInfo: CommonLockInfo{Height: 110}, Hash: &Hash{1}}},
+	}
+
+	inbox := NewPartialInbox(tr.a.PublicAccount, source, testClock(t))
+	assert.Nil(t, inbox.Refresh(ctx))
+
+	status := inbox.Partial(&Hash{1})
+	assert.NotNil(t, status)
+	assert.False(t, status.Complete)
+	assert.Equal(t, Height(110), status.LockExpiryHeight)
+	assert.Equal(t, testClockStart.Add(100*time.Second), status.LockExpiry)
+
+	signer := status.InnerSigners[0]
+	assert.Equal(t, tr.treasury, signer.Account)
+	assert.False(t, signer.Approved)
+	assert.True(t, signer.Reachable)
+	assert.Equal(t, []*PublicAccount{tr.a.PublicAccount}, signer.Signed)
+	assert.ElementsMatch(t, []*PublicAccount{tr.b.PublicAccount, tr.c.PublicAccount, tr.d.PublicAccount}, signer.Missing)
+	assert.Len(t, signer.MissingSets, 3)
+	assert.Len(t, signer.MissingSets[0], 1)
+
+	status, err := inbox.AddCosignature(&SignerInfo{Signer: tr.d.PublicAccount.PublicKey, ParentHash: &Hash{1}})
+	assert.Nil(t, err)
+	assert.True(t, status.Complete)
+	assert.True(t, status.InnerSigners[0].Approved)
+	assert.Len(t, status.Aggregate.Cosignatures, 1)
+	assert.Equal(t, status, inbox.Partial(&Hash{1}))
+
+	// the same cosignature is ignored
+	status, err = inbox.AddCosignature(&SignerInfo{Signer: tr.d.PublicAccount.PublicKey, ParentHash: &Hash{1}})
+	assert.Nil(t, err)
+	assert.Len(t, status.Aggregate.Cosignatures, 1)
+
+	status, err = inbox.AddCosignature(&SignerInfo{Signer: tr.d.PublicAccount.PublicKey, ParentHash: &Hash{9}})
+	assert.Nil(t, err)
+	assert.Nil(t, status)
+
+	// signer which isn't multisig approves by itself
+	status, err = inbox.AddPartial(ctx, testPartial(&Hash{2}, tr.a.PublicAccount, outsider.PublicAccount))
+	assert.Nil(t, err)
+	assert.False(t, status.Complete)
+	assert.Equal(t, Height(0), status.LockExpiryHeight)
+	assert.Equal(t, [][]*PublicAccount{{outsider.PublicAccount}}, status.InnerSigners[0].MissingSets)
+
+	_, err = inbox.AddPartial(ctx, testPartial(&Hash{3}, tr.a.PublicAccount, outsider.PublicAccount))
+	assert.Nil(t, err)
+	// graph of every inner signer is requested once
+	assert.Equal(t, 2, source.graphRequests)
+
+	partials := inbox.Partials()
+	assert.Len(t, partials, 3)
+	assert.Equal(t, &Hash{1}, partials[2].Hash)
+
+	inbox.RemovePartial(&Hash{1})
+	assert.Nil(t, inbox.Partial(&Hash{1}))
+	assert.Len(t, inbox.Partials(), 2)
+}
+
+func TestPartialInbox_RefreshKeepsUpdates(t *testing.T) {
+	tr := newTestTreasury(t)
+	outsider := testMultisigOutsider(t)
+
+	source := &fakePartialInboxSource{
+		partials: []*AggregateTransaction{
+			testPartial(&Hash{1}, tr.a.PublicAccount, tr.treasury),
+			testPartial(&Hash{2}, tr.a.PublicAccount, outsider.PublicAccount),
+			testPartial(&Hash{3}, tr.a.PublicAccount, outsider.PublicAccount),
+		},
+		graphs: map[string]*MultisigAccountGraphInfo{tr.treasury.Address.Address: tr.graph},
+	}
+
+	inbox := NewPartialInbox(tr.a.PublicAccount, source, nil)
+
+	// cosignature received before partial is tracked
+	status, err := inbox.AddCosignature(&SignerInfo{Signer: tr.d.PublicAccount.PublicKey, ParentHash: &Hash{1}})
+	assert.Nil(t, err)
+	assert.Nil(t, status)
+
+	source.onRequest = func() {
+		inbox.RemovePartial(&Hash{2})
+
+		_, err := inbox.AddCosignature(&SignerInfo{Signer: outsider.PublicAccount.PublicKey, ParentHash: &Hash{3}})
+		assert.Nil(t, err)
+
+		_, err = inbox.AddPartial(ctx, testPartial(&Hash{4}, tr.a.PublicAccount, outsider.PublicAccount))
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, inbox.Refresh(ctx))
+
+	assert.True(t, inbox.Partial(&Hash{1}).Complete)
+	assert.Len(t, inbox.Partial(&Hash{1}).Aggregate.Cosignatures, 1)
+	// partial removed during refresh isn't resurrected by its result
+	assert.Nil(t, inbox.Partial(&Hash{2}))
+	assert.True(t, inbox.Partial(&Hash{3}).Complete)
+	assert.NotNil(t, inbox.Partial(&Hash{4}))
+	assert.Len(t, inbox.Partials(), 3)
+}
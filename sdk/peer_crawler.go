@@ -0,0 +1,319 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCrawlerConcurrency = 8
+	defaultRestPort           = 3000
+	defaultRestScheme         = "http"
+)
+
+// nodePeersSource is the part of NodeService used by PeerCrawler
+type nodePeersSource interface {
+	GetNodeInfo(ctx context.Context) (*NodeInfo, error)
+	GetNodePeers(ctx context.Context) ([]*NodeInfo, error)
+}
+
+type PeerCrawlerConfig struct {
+	// Maximum number of nodes requested at the same time. Zero means 8
+	Concurrency int
+	// Crawling stops scheduling new nodes when MaxNodes nodes are known. Zero means no limit
+	MaxNodes int
+	// Port and scheme of REST API on API nodes, peers report only p2p port. Zero values mean 3000 and http
+	RestPort   int
+	RestScheme string
+}
+
+// NetworkNode is a node found by PeerCrawler
+type NetworkNode struct {
+	*NodeInfo
+	// REST url of API node, empty for peer only nodes
+	URL string
+	// True when REST API of node answered the probe
+	RestAvailable bool
+	// Response time of node info request
+	Latency time.Duration
+	// Public keys of peers reported by node. Empty when node is not requested
+	Peers []string
+	// Error of REST probe
+	Err error
+}
+
+func (n *NetworkNode) String() string {
+	return fmt.Sprintf(
+		`{ "NodeInfo": %s, "URL": %s, "RestAvailable": %t, "Latency": %s, "Peers": %v }`,
+		n.NodeInfo,
+		n.URL,
+		n.RestAvailable,
+		n.Latency,
+		n.Peers,
+	)
+}
+
+// NetworkMap contains nodes found by PeerCrawler indexed by public key
+type NetworkMap struct {
+	Nodes map[string]*NetworkNode
+	// Seed urls which couldn't be requested
+	UnreachableSeeds map[string]error
+}
+
+// returns nodes with passed roles. Without roles all nodes are returned
+func (m *NetworkMap) NodesWithRoles(roles ...NodeRole) []*NetworkNode {
+	nodes := make([]*NetworkNode, 0)
+	for _, n := range m.Nodes {
+		matches := true
+		for _, role := range roles {
+			if n.Roles&int(role) == 0 {
+				matches = false
+			}
+		}
+
+		if matches {
+			nodes = append(nodes, n)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Account.PublicKey < nodes[j].Account.PublicKey
+	})
+
+	return nodes
+}
+
+// returns REST urls of available API nodes sorted by latency. The result can be passed to NewConfig
+func (m *NetworkMap) BaseURLs() []string {
+	nodes := make([]*NetworkNode, 0)
+	for _, n := range m.Nodes {
+		if n.RestAvailable {
+			nodes = append(nodes, n)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Latency != nodes[j].Latency {
+			return nodes[i].Latency < nodes[j].Latency
+		}
+		return nodes[i].URL < nodes[j].URL
+	})
+
+	urls := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		urls = append(urls, n.URL)
+	}
+
+	return urls
+}
+
+// PeerCrawler walks peers of nodes recursively starting from seed nodes
+type PeerCrawler struct {
+	config    PeerCrawlerConfig
+	seeds     []string
+	newSource func(u url.URL) nodePeersSource
+}
+
+// returns crawler which requests nodes with the same config as client. Config.BaseURLs are used as default seeds
+func (c *Client) NewPeerCrawler(cfg PeerCrawlerConfig) *PeerCrawler {
+	seeds := make([]string, 0, len(c.config.BaseURLs))
+	for _, u := range c.config.BaseURLs {
+		seeds = append(seeds, u.String())
+	}
+
+	return newPeerCrawler(cfg, seeds, func(u url.URL) nodePeersSource {
+		return c.nodeClient(u).Node
+	})
+}
+
+func newPeerCrawler(cfg PeerCrawlerConfig, seeds []string, newSource func(u url.URL) nodePeersSource) *PeerCrawler {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaultCrawlerConcurrency
+	}
+
+	if cfg.RestPort == 0 {
+		cfg.RestPort = defaultRestPort
+	}
+
+	if cfg.RestScheme == "" {
+		cfg.RestScheme = defaultRestScheme
+	}
+
+	return &PeerCrawler{config: cfg, seeds: seeds, newSource: newSource}
+}
+
+type crawl struct {
+	*PeerCrawler
+	sync.Mutex
+	wg        sync.WaitGroup
+	semaphore chan struct{}
+	visited   map[string]bool
+	result    *NetworkMap
+}
+
+// Crawl requests seed nodes and then API nodes found among peers until no new nodes are found.
+// Peer only nodes are added to the map but can't be requested. Without seeds the default ones are used
+func (c *PeerCrawler) Crawl(ctx context.Context, seeds ...string) (*NetworkMap, error) {
+	if len(seeds) == 0 {
+		seeds = c.seeds
+	}
+
+	if len(seeds) == 0 {
+		return nil, ErrEmptyBaseURLs
+	}
+
+	cr := &crawl{
+		PeerCrawler: c,
+		semaphore:   make(chan struct{}, c.config.Concurrency),
+		visited:     make(map[string]bool),
+		result: &NetworkMap{
+			Nodes:            make(map[string]*NetworkNode),
+			UnreachableSeeds: make(map[string]error),
+		},
+	}
+
+	for _, seed := range seeds {
+		u, err := url.Parse(seed)
+		if err != nil {
+			return nil, err
+		}
+
+		cr.schedule(ctx, *u, true)
+	}
+
+	cr.wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return cr.result, nil
+}
+
+func (cr *crawl) schedule(ctx context.Context, u url.URL, seed bool) {
+	cr.Lock()
+	defer cr.Unlock()
+
+	if cr.visited[u.String()] {
+		return
+	}
+	cr.visited[u.String()] = true
+
+	cr.wg.Add(1)
+	go func() {
+		defer cr.wg.Done()
+
+		select {
+		case <-ctx.Done():
+			return
+		case cr.semaphore <- struct{}{}:
+		}
+		defer func() { <-cr.semaphore }()
+
+		cr.visit(ctx, u, seed)
+	}()
+}
+
+func (cr *crawl) visit(ctx context.Context, u url.URL, seed bool) {
+	source := cr.newSource(u)
+
+	start := time.Now()
+	info, err := source.GetNodeInfo(ctx)
+	latency := time.Since(start)
+	if err == nil && info.Account == nil {
+		err = ErrNilAccount
+	}
+	if err != nil {
+		cr.probeFailed(u, seed, err)
+		return
+	}
+
+	peers, err := source.GetNodePeers(ctx)
+	if err != nil {
+		cr.probeFailed(u, seed, err)
+		return
+	}
+
+	cr.Lock()
+
+	node := cr.node(info)
+	node.URL = u.String()
+	node.RestAvailable = true
+	node.Latency = latency
+	node.Err = nil
+	node.Peers = make([]string, 0, len(peers))
+
+	next := make([]url.URL, 0)
+	for _, peer := range peers {
+		if peer.Account == nil {
+			continue
+		}
+
+		node.Peers = append(node.Peers, peer.Account.PublicKey)
+
+		if _, ok := cr.result.Nodes[peer.Account.PublicKey]; !ok && cr.full() {
+			continue
+		}
+
+		// peer without host can't be requested, empty host would mean local node
+		peerNode := cr.node(peer)
+		if peer.IsApiNode() && peer.Host != "" && peerNode.URL == "" {
+			peerURL := cr.restURL(peer)
+			peerNode.URL = peerURL.String()
+			next = append(next, peerURL)
+		}
+	}
+
+	cr.Unlock()
+
+	for _, n := range next {
+		cr.schedule(ctx, n, false)
+	}
+}
+
+func (cr *crawl) probeFailed(u url.URL, seed bool, err error) {
+	cr.Lock()
+	defer cr.Unlock()
+
+	if seed {
+		cr.result.UnreachableSeeds[u.String()] = err
+	}
+
+	for _, n := range cr.result.Nodes {
+		if n.URL == u.String() {
+			n.Err = err
+		}
+	}
+}
+
+// returns node from map, adding it when it is not known. Should be called under lock
+func (cr *crawl) node(info *NodeInfo) *NetworkNode {
+	node, ok := cr.result.Nodes[info.Account.PublicKey]
+	if !ok {
+		node = &NetworkNode{NodeInfo: info}
+		cr.result.Nodes[info.Account.PublicKey] = node
+	}
+
+	return node
+}
+
+func (cr *crawl) full() bool {
+	return cr.config.MaxNodes > 0 && len(cr.result.Nodes) >= cr.config.MaxNodes
+}
+
+func (cr *crawl) restURL(info *NodeInfo) url.URL {
+	return url.URL{
+		Scheme: cr.config.RestScheme,
+		Host:   net.JoinHostPort(info.Host, strconv.Itoa(cr.config.RestPort)),
+	}
+}
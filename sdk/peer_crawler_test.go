@@ -0,0 +1,122 @@
+// Copyright 2020 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePeersSource struct {
+	info  *NodeInfo
+	peers []*NodeInfo
+	err   error
+}
+
+func (s *fakePeersSource) GetNodeInfo(context.Context) (*NodeInfo, error) {
+	return s.info, s.err
+}
+
+func (s *fakePeersSource) GetNodePeers(context.Context) ([]*NodeInfo, error) {
+	return s.peers, nil
+}
+
+func newTestPeerInfo(t *testing.T, i int, roles NodeRole) *NodeInfo {
+	account, err := NewAccountFromPublicKey(fmt.Sprintf("%064X", i), MijinTest)
+	assert.Nil(t, err)
+
+	return &NodeInfo{
+		Account:     account,
+		Host:        fmt.Sprintf("node%d", i),
+		Port:        7900,
+		NetworkType: MijinTest,
+		Roles:       int(roles),
+	}
+}
+
+func TestPeerCrawler_Crawl(t *testing.T) {
+	var (
+		api1     = newTestPeerInfo(t, 1, Peer|Api)
+		api2     = newTestPeerInfo(t, 2, Peer|Api)
+		api3     = newTestPeerInfo(t, 3, Api)
+		peerOnly = newTestPeerInfo(t, 4, Peer)
+		down     = newTestPeerInfo(t, 5, Peer|Api)
+		hostless = newTestPeerInfo(t, 6, Api)
+
+		lock      sync.Mutex
+		requested = make(map[string]int)
+	)
+
+	hostless.Host = ""
+
+	sources := map[string]*fakePeersSource{
+		"http://seed:3000":  {info: api1, peers: []*NodeInfo{api2, peerOnly}},
+		"http://node2:3000": {info: api2, peers: []*NodeInfo{api1, api3, down}},
+		"http://node3:3000": {info: api3, peers: []*NodeInfo{api2, hostless}},
+		"http://node5:3000": {err: errors.New("timeout")},
+		"http://other:3000": {err: errors.New("timeout")},
+	}
+
+	crawler := newPeerCrawler(PeerCrawlerConfig{Concurrency: 2}, []string{"http://seed:3000"}, func(u url.URL) nodePeersSource {
+		lock.Lock()
+		requested[u.String()]++
+		lock.Unlock()
+
+		return sources[u.String()]
+	})
+
+	networkMap, err := crawler.Crawl(context.Background())
+	assert.Nil(t, err)
+
+	assert.Len(t, networkMap.Nodes, 6)
+	assert.Len(t, networkMap.NodesWithRoles(Api), 5)
+	assert.Len(t, networkMap.NodesWithRoles(Peer, Api), 3)
+	assert.Equal(t, peerOnly, networkMap.NodesWithRoles(Peer)[2].NodeInfo)
+
+	assert.Equal(t, "", networkMap.Nodes[peerOnly.Account.PublicKey].URL)
+	// API node without host isn't probed, so local node isn't requested instead of it
+	assert.Equal(t, "", networkMap.Nodes[hostless.Account.PublicKey].URL)
+	assert.False(t, networkMap.Nodes[down.Account.PublicKey].RestAvailable)
+	assert.NotNil(t, networkMap.Nodes[down.Account.PublicKey].Err)
+	assert.Equal(t, []string{api2.Account.PublicKey, peerOnly.Account.PublicKey}, networkMap.Nodes[api1.Account.PublicKey].Peers)
+
+	assert.ElementsMatch(t, []string{"http://seed:3000", "http://node2:3000", "http://node3:3000"}, networkMap.BaseURLs())
+
+	// every node is requested once even if it is reported by several peers
+	for u, count := range requested {
+		assert.Equal(t, 1, count, u)
+	}
+	assert.Len(t, requested, 4)
+
+	networkMap, err = crawler.Crawl(context.Background(), "http://other:3000")
+	assert.Nil(t, err)
+	assert.Empty(t, networkMap.Nodes)
+	assert.Contains(t, networkMap.UnreachableSeeds, "http://other:3000")
+}
+
+func TestPeerCrawler_Crawl_MaxNodes(t *testing.T) {
+	api1 := newTestPeerInfo(t, 1, Api)
+	peers := []*NodeInfo{newTestPeerInfo(t, 2, Api), newTestPeerInfo(t, 3, Api), newTestPeerInfo(t, 4, Api)}
+
+	crawler := newPeerCrawler(PeerCrawlerConfig{MaxNodes: 2}, nil, func(u url.URL) nodePeersSource {
+		if u.String() == "http://seed:3000" {
+			return &fakePeersSource{info: api1, peers: peers}
+		}
+		return &fakePeersSource{err: errors.New("timeout")}
+	})
+
+	networkMap, err := crawler.Crawl(context.Background(), "http://seed:3000")
+	assert.Nil(t, err)
+	assert.Len(t, networkMap.Nodes, 2)
+
+	_, err = crawler.Crawl(context.Background())
+	assert.Equal(t, ErrEmptyBaseURLs, err)
+}
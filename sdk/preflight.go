@@ -0,0 +1,238 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+type preflightSource interface {
+	GetAccountInfo(ctx context.Context, address *Address) (*AccountInfo, error)
+	GetMosaicInfoByAssetId(ctx context.Context, assetId AssetId) (*MosaicInfo, error)
+	GetNetworkConfig(ctx context.Context) (*BlockchainConfig, error)
+}
+
+type clientPreflightSource struct {
+	*AccountService
+	*ResolverService
+	*NetworkService
+}
+
+// MosaicShortfall is a mosaic which signer doesn't have enough to pay for transaction
+type MosaicShortfall struct {
+	MosaicId  *MosaicId
+	Required  Amount
+	Available Amount
+}
+
+// returns amount which signer lacks
+func (s *MosaicShortfall) Missing() Amount {
+	return s.Required - s.Available
+}
+
+// PreflightReport compares total debit of transaction with balances of signer
+type PreflightReport struct {
+	Signer *PublicAccount
+	// Total debit of signer including max fee, mosaics are resolved and ordered by id
+	Debits     []*Mosaic
+	Shortfalls []*MosaicShortfall
+	// Transactions whose deposits depend on chain state, e.g. drive deposits, so they aren't included to Debits
+	Unestimated []Transaction
+}
+
+// returns true when signer has enough of every debited mosaic
+func (r *PreflightReport) Sufficient() bool {
+	return len(r.Shortfalls) == 0
+}
+
+// Preflight computes total debit of transaction for signer and compares it with balances of signer.
+// Debits cover transferred, locked, deposited and offered mosaics and max fee. Inner transactions of aggregate
+// are included when they are signed by signer or don't have signer yet, while inner transactions of other accounts
+// are debited from those accounts. Max fee and exchange costs are paid in currency mosaic from network config,
+// mosaics referenced by namespace aliases are resolved by ResolverService
+func (c *Client) Preflight(ctx context.Context, signer *PublicAccount, tx Transaction) (*PreflightReport, error) {
+	return preflight(ctx, &clientPreflightSource{c.Account, c.Resolve, c.Network}, signer, tx)
+}
+
+func preflight(ctx context.Context, source preflightSource, signer *PublicAccount, tx Transaction) (*PreflightReport, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	if signer == nil {
+		signer = tx.GetAbstractTransaction().Signer
+	}
+
+	if signer == nil {
+		return nil, ErrNilAccount
+	}
+
+	config, err := source.GetNetworkConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.NetworkConfig == nil {
+		return nil, ErrNilNetworkConfig
+	}
+
+	currency, err := config.NetworkConfig.CurrencyMosaicId()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &PreflightReport{
+		Signer:      signer,
+		Debits:      make([]*Mosaic, 0),
+		Shortfalls:  make([]*MosaicShortfall, 0),
+		Unestimated: make([]Transaction, 0),
+	}
+
+	debits := make([]*Mosaic, 0)
+	if fee := tx.GetAbstractTransaction().MaxFee; fee > 0 {
+		debits = append(debits, newMosaicPanic(currency, fee))
+	}
+
+	inner := []Transaction{tx}
+	if aggregate, ok := tx.(*AggregateTransaction); ok {
+		inner = aggregate.InnerTransactions
+	}
+
+	for _, t := range inner {
+		if s := t.GetAbstractTransaction().Signer; t != tx && s != nil && s.PublicKey != signer.PublicKey {
+			continue
+		}
+
+		mosaics, ok := transactionDebits(t, currency)
+		if !ok {
+			report.Unestimated = append(report.Unestimated, t)
+		}
+
+		debits = append(debits, mosaics...)
+	}
+
+	totals, err := resolvedTotals(ctx, source, debits)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uint64]Amount)
+	info, err := source.GetAccountInfo(ctx, signer.Address)
+	if err != nil && err != ErrResourceNotFound {
+		return nil, err
+	}
+
+	if info != nil {
+		for _, m := range info.Mosaics {
+			balances[m.AssetId.Id()] += m.Amount
+		}
+	}
+
+	for _, m := range totals {
+		report.Debits = append(report.Debits, m)
+
+		if available := balances[m.AssetId.Id()]; available < m.Amount {
+			report.Shortfalls = append(report.Shortfalls, &MosaicShortfall{
+				MosaicId:  m.AssetId.(*MosaicId),
+				Required:  m.Amount,
+				Available: available,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// returns mosaics debited from signer by transaction itself without max fee.
+// Returns false when transaction has debits which depend on chain state
+func transactionDebits(tx Transaction, currency AssetId) ([]*Mosaic, bool) {
+	switch t := tx.(type) {
+	case *TransferTransaction:
+		return t.Mosaics, true
+	case *LockFundsTransaction:
+		return []*Mosaic{t.Mosaic}, true
+	case *SecretLockTransaction:
+		return []*Mosaic{t.Mosaic}, true
+	case *AddExchangeOfferTransaction:
+		debits := make([]*Mosaic, len(t.Offers))
+		for i, o := range t.Offers {
+			debits[i] = offerDeposit(&o.Offer, o.Type, currency)
+		}
+
+		return debits, true
+	case *ExchangeOfferTransaction:
+		// accepting offer is the counter operation, so signer pays what owner of offer gets
+		debits := make([]*Mosaic, len(t.Confirmations))
+		for i, c := range t.Confirmations {
+			debits[i] = offerDeposit(&c.Offer, c.Type.CounterOffer(), currency)
+		}
+
+		return debits, true
+	case *StartExecuteTransaction:
+		return t.LockMosaics, true
+	case *JoinToDriveTransaction, *FilesDepositTransaction:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// returns mosaic which is paid by side of exchange with passed offer type. Cost is paid in currency
+func offerDeposit(offer *Offer, offerType OfferType, currency AssetId) *Mosaic {
+	if offerType == BuyOffer {
+		return newMosaicPanic(currency, offer.Cost)
+	}
+
+	return offer.Mosaic
+}
+
+// returns sums of mosaics by mosaic ids with namespace aliases resolved, ordered by id
+func resolvedTotals(ctx context.Context, source preflightSource, mosaics []*Mosaic) ([]*Mosaic, error) {
+	resolved := make(map[uint64]*MosaicId)
+	totals := make(map[uint64]*Mosaic)
+
+	for _, m := range mosaics {
+		if m == nil || m.AssetId == nil {
+			return nil, ErrNilAssetId
+		}
+
+		var mosaicId *MosaicId
+		switch m.AssetId.Type() {
+		case NamespaceAssetIdType:
+			var ok bool
+			if mosaicId, ok = resolved[m.AssetId.Id()]; !ok {
+				info, err := source.GetMosaicInfoByAssetId(ctx, m.AssetId)
+				if err != nil {
+					return nil, errors.Wrapf(err, "resolving %s", m.AssetId)
+				}
+
+				mosaicId = info.MosaicId
+				resolved[m.AssetId.Id()] = mosaicId
+			}
+		default:
+			mosaicId = m.AssetId.(*MosaicId)
+		}
+
+		if total, ok := totals[mosaicId.Id()]; ok {
+			total.Amount += m.Amount
+		} else {
+			totals[mosaicId.Id()] = newMosaicPanic(mosaicId, m.Amount)
+		}
+	}
+
+	result := make([]*Mosaic, 0, len(totals))
+	for _, m := range totals {
+		result = append(result, m)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].AssetId.Id() < result[j].AssetId.Id()
+	})
+
+	return result, nil
+}
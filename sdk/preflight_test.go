@@ -0,0 +1,123 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testCurrencyMosaicId = newMosaicIdPanic(0x0DC67FBE1CAD29E3)
+
+type fakePreflightSource struct {
+	info     *AccountInfo
+	config   *NetworkConfig
+	resolved int
+}
+
+func (s *fakePreflightSource) GetAccountInfo(context.Context, *Address) (*AccountInfo, error) {
+	if s.info == nil {
+		return nil, ErrResourceNotFound
+	}
+
+	return s.info, nil
+}
+
+func (s *fakePreflightSource) GetMosaicInfoByAssetId(_ context.Context, assetId AssetId) (*MosaicInfo, error) {
+	s.resolved++
+
+	if assetId.Id() != XpxNamespaceId.Id() {
+		return nil, ErrResourceNotFound
+	}
+
+	return &MosaicInfo{MosaicId: testCurrencyMosaicId}, nil
+}
+
+func (s *fakePreflightSource) GetNetworkConfig(context.Context) (*BlockchainConfig, error) {
+	return &BlockchainConfig{NetworkConfig: s.config}, nil
+}
+
+func TestPreflight(t *testing.T) {
+	signer := testMultisigOutsider(t).PublicAccount
+	other := newTestTreasury(t).a.PublicAccount
+	storage := newMosaicIdPanic(1)
+
+	transfer, err := NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{
+		newMosaicPanic(XpxNamespaceId, 100),
+		newMosaicPanic(storage, 10),
+	}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+	transfer.ToAggregate(signer)
+
+	offer, err := NewAddExchangeOfferTransaction(fakeDeadline, []*AddOffer{
+		{Offer{BuyOffer, newMosaicPanic(storage, 5), 50}, 100},
+		{Offer{SellOffer, newMosaicPanic(storage, 5), 50}, 100},
+	}, MijinTest)
+	assert.Nil(t, err)
+	offer.ToAggregate(signer)
+
+	// transfer of other account is debited from that account
+	otherTransfer, err := NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{newMosaicPanic(storage, 1000)}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+	otherTransfer.ToAggregate(other)
+
+	join, err := NewJoinToDriveTransaction(fakeDeadline, other, MijinTest)
+	assert.Nil(t, err)
+	join.ToAggregate(signer)
+
+	aggregate, err := NewCompleteAggregateTransaction(fakeDeadline, []Transaction{transfer, offer, otherTransfer, join}, MijinTest)
+	assert.Nil(t, err)
+	aggregate.MaxFee = 25
+
+	source := &fakePreflightSource{info: &AccountInfo{Mosaics: []*Mosaic{
+		newMosaicPanic(testCurrencyMosaicId, 200),
+		newMosaicPanic(storage, 10),
+	}}, config: testNetworkConfig(t)}
+
+	report, err := preflight(ctx, source, signer, aggregate)
+	assert.Nil(t, err)
+	// only alias of transfer is resolved, fee and cost of buy offer are in currency mosaic from config
+	assert.Equal(t, 1, source.resolved)
+	assert.Equal(t, []*Mosaic{newMosaicPanic(storage, 15), newMosaicPanic(testCurrencyMosaicId, 175)}, report.Debits)
+	assert.Equal(t, []Transaction{join}, report.Unestimated)
+	assert.False(t, report.Sufficient())
+	assert.Equal(t, []*MosaicShortfall{{storage, 15, 10}}, report.Shortfalls)
+	assert.Equal(t, Amount(5), report.Shortfalls[0].Missing())
+
+	// account unknown to the node doesn't have any balance
+	source.info = nil
+	report, err = preflight(ctx, source, signer, aggregate)
+	assert.Nil(t, err)
+	assert.Len(t, report.Shortfalls, 2)
+}
+
+func TestPreflight_ExchangeOffer(t *testing.T) {
+	signer := testMultisigOutsider(t).PublicAccount
+	storage := newMosaicIdPanic(1)
+
+	// accepting sell offer pays cost, accepting buy offer pays mosaic
+	tx, err := NewExchangeOfferTransaction(fakeDeadline, []*ExchangeConfirmation{
+		{Offer{SellOffer, newMosaicPanic(storage, 5), 50}, signer},
+		{Offer{BuyOffer, newMosaicPanic(storage, 7), 70}, signer},
+	}, MijinTest)
+	assert.Nil(t, err)
+
+	source := &fakePreflightSource{info: &AccountInfo{Mosaics: []*Mosaic{
+		newMosaicPanic(testCurrencyMosaicId, 50),
+		newMosaicPanic(storage, 7),
+	}}, config: testNetworkConfig(t)}
+
+	report, err := preflight(ctx, source, signer, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*Mosaic{newMosaicPanic(storage, 7), newMosaicPanic(testCurrencyMosaicId, 50)}, report.Debits)
+	assert.True(t, report.Sufficient())
+	assert.Equal(t, 0, source.resolved)
+
+	source.config = nil
+	_, err = preflight(ctx, source, signer, tx)
+	assert.Equal(t, ErrNilNetworkConfig, err)
+}
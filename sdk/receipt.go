@@ -0,0 +1,63 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/proximax-storage/go-xpx-utils/net"
+)
+
+type ReceiptService service
+
+// returns receipts and resolution statements of block at passed height
+func (r *ReceiptService) GetBlockReceipts(ctx context.Context, height Height) (*BlockStatement, error) {
+	if height == 0 {
+		return nil, ErrNilOrZeroHeight
+	}
+
+	url := net.NewUrl(fmt.Sprintf(blockReceiptsRoute, height))
+
+	dto := &blockStatementDTO{}
+
+	resp, err := r.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handleResponseStatusCode(resp, map[int]error{404: ErrResourceNotFound, 409: ErrArgumentNotValid}); err != nil {
+		return nil, err
+	}
+
+	return dto.toStruct(r.client.NetworkType())
+}
+
+// returns merkle path from hash of statement to BlockReceiptsHash of block
+func (r *ReceiptService) GetReceiptMerklePath(ctx context.Context, height Height, hash *Hash) ([]*MerklePathItem, error) {
+	if height == 0 {
+		return nil, ErrNilOrZeroHeight
+	}
+
+	if hash == nil {
+		return nil, ErrNilHash
+	}
+
+	url := net.NewUrl(fmt.Sprintf(blockReceiptMerkleRoute, height, hash))
+
+	dto := &merkleProofDTO{}
+
+	resp, err := r.client.doNewRequest(ctx, http.MethodGet, url.Encode(), nil, dto)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = handleResponseStatusCode(resp, map[int]error{404: ErrResourceNotFound, 409: ErrArgumentNotValid}); err != nil {
+		return nil, err
+	}
+
+	return dto.toStruct()
+}
@@ -0,0 +1,298 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	jsonLib "encoding/json"
+)
+
+type receiptSourceDTO struct {
+	PrimaryId   uint32 `json:"primaryId"`
+	SecondaryId uint32 `json:"secondaryId"`
+}
+
+func (dto *receiptSourceDTO) toStruct() ReceiptSource {
+	return ReceiptSource{
+		PrimaryId:   dto.PrimaryId,
+		SecondaryId: dto.SecondaryId,
+	}
+}
+
+type receiptHeaderDTO struct {
+	Version uint16      `json:"version"`
+	Type    ReceiptType `json:"type"`
+}
+
+func (dto *receiptHeaderDTO) toStruct() ReceiptHeader {
+	return ReceiptHeader{
+		Version: dto.Version,
+		Type:    dto.Type,
+	}
+}
+
+type balanceTransferReceiptDTO struct {
+	receiptHeaderDTO
+	Sender    string    `json:"sender"`
+	Recipient string    `json:"recipient"`
+	MosaicId  uint64DTO `json:"mosaicId"`
+	Amount    uint64DTO `json:"amount"`
+}
+
+func (dto *balanceTransferReceiptDTO) toStruct(networkType NetworkType) (Receipt, error) {
+	sender, err := NewAccountFromPublicKey(dto.Sender, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	recipient, err := NewAddressFromBase32(dto.Recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	mosaicId, err := NewAssetIdFromId(dto.MosaicId.toUint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceTransferReceipt{
+		ReceiptHeader: dto.receiptHeaderDTO.toStruct(),
+		Sender:        sender,
+		Recipient:     recipient,
+		MosaicId:      mosaicId,
+		Amount:        dto.Amount.toStruct(),
+	}, nil
+}
+
+type balanceChangeReceiptDTO struct {
+	receiptHeaderDTO
+	Account  string    `json:"account"`
+	MosaicId uint64DTO `json:"mosaicId"`
+	Amount   uint64DTO `json:"amount"`
+}
+
+func (dto *balanceChangeReceiptDTO) toStruct(networkType NetworkType) (Receipt, error) {
+	account, err := NewAccountFromPublicKey(dto.Account, networkType)
+	if err != nil {
+		return nil, err
+	}
+
+	mosaicId, err := NewAssetIdFromId(dto.MosaicId.toUint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BalanceChangeReceipt{
+		ReceiptHeader: dto.receiptHeaderDTO.toStruct(),
+		Account:       account,
+		MosaicId:      mosaicId,
+		Amount:        dto.Amount.toStruct(),
+	}, nil
+}
+
+type artifactExpiryReceiptDTO struct {
+	receiptHeaderDTO
+	ArtifactId uint64DTO `json:"artifactId"`
+}
+
+func (dto *artifactExpiryReceiptDTO) toStruct() (Receipt, error) {
+	artifactId, err := NewAssetIdFromId(dto.ArtifactId.toUint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArtifactExpiryReceipt{
+		ReceiptHeader: dto.receiptHeaderDTO.toStruct(),
+		ArtifactId:    artifactId,
+	}, nil
+}
+
+type inflationReceiptDTO struct {
+	receiptHeaderDTO
+	MosaicId uint64DTO `json:"mosaicId"`
+	Amount   uint64DTO `json:"amount"`
+}
+
+func (dto *inflationReceiptDTO) toStruct() (Receipt, error) {
+	mosaicId, err := NewAssetIdFromId(dto.MosaicId.toUint64())
+	if err != nil {
+		return nil, err
+	}
+
+	return &InflationReceipt{
+		ReceiptHeader: dto.receiptHeaderDTO.toStruct(),
+		MosaicId:      mosaicId,
+		Amount:        dto.Amount.toStruct(),
+	}, nil
+}
+
+// maps receipt by its basic type, so receipts of new plugins with known layout are typed as well
+func mapReceipt(data jsonLib.RawMessage, networkType NetworkType) (Receipt, error) {
+	header := receiptHeaderDTO{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.Type.BasicType() {
+	case BalanceTransferBasicType:
+		dto := balanceTransferReceiptDTO{}
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, err
+		}
+		return dto.toStruct(networkType)
+	case BalanceCreditBasicType, BalanceDebitBasicType:
+		dto := balanceChangeReceiptDTO{}
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, err
+		}
+		return dto.toStruct(networkType)
+	case ArtifactExpiryBasicType:
+		dto := artifactExpiryReceiptDTO{}
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, err
+		}
+		return dto.toStruct()
+	case InflationBasicType:
+		dto := inflationReceiptDTO{}
+		if err := json.Unmarshal(data, &dto); err != nil {
+			return nil, err
+		}
+		return dto.toStruct()
+	default:
+		return &UnknownReceipt{
+			ReceiptHeader: header.toStruct(),
+			Raw:           data,
+		}, nil
+	}
+}
+
+type transactionStatementDTO struct {
+	Height   uint64DTO            `json:"height"`
+	Source   receiptSourceDTO     `json:"source"`
+	Receipts []jsonLib.RawMessage `json:"receipts"`
+}
+
+func (dto *transactionStatementDTO) toStruct(networkType NetworkType) (*TransactionStatement, error) {
+	receipts := make([]Receipt, len(dto.Receipts))
+	for i, r := range dto.Receipts {
+		receipt, err := mapReceipt(r, networkType)
+		if err != nil {
+			return nil, err
+		}
+
+		receipts[i] = receipt
+	}
+
+	return &TransactionStatement{
+		Height:   dto.Height.toStruct(),
+		Source:   dto.Source.toStruct(),
+		Receipts: receipts,
+	}, nil
+}
+
+type addressResolutionStatementDTO struct {
+	Height            uint64DTO `json:"height"`
+	Unresolved        string    `json:"unresolved"`
+	ResolutionEntries []struct {
+		Source   receiptSourceDTO `json:"source"`
+		Resolved string           `json:"resolved"`
+	} `json:"resolutionEntries"`
+}
+
+func (dto *addressResolutionStatementDTO) toStruct() (*AddressResolutionStatement, error) {
+	unresolved, err := NewAddressFromBase32(dto.Unresolved)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*AddressResolutionEntry, len(dto.ResolutionEntries))
+	for i, e := range dto.ResolutionEntries {
+		resolved, err := NewAddressFromBase32(e.Resolved)
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = &AddressResolutionEntry{
+			Source:   e.Source.toStruct(),
+			Resolved: resolved,
+		}
+	}
+
+	return &AddressResolutionStatement{
+		Height:            dto.Height.toStruct(),
+		Unresolved:        unresolved,
+		ResolutionEntries: entries,
+	}, nil
+}
+
+type mosaicResolutionStatementDTO struct {
+	Height            uint64DTO `json:"height"`
+	Unresolved        uint64DTO `json:"unresolved"`
+	ResolutionEntries []struct {
+		Source   receiptSourceDTO `json:"source"`
+		Resolved uint64DTO        `json:"resolved"`
+	} `json:"resolutionEntries"`
+}
+
+func (dto *mosaicResolutionStatementDTO) toStruct() (*MosaicResolutionStatement, error) {
+	unresolved, err := NewAssetIdFromId(dto.Unresolved.toUint64())
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*MosaicResolutionEntry, len(dto.ResolutionEntries))
+	for i, e := range dto.ResolutionEntries {
+		resolved, err := NewMosaicId(e.Resolved.toUint64())
+		if err != nil {
+			return nil, err
+		}
+
+		entries[i] = &MosaicResolutionEntry{
+			Source:   e.Source.toStruct(),
+			Resolved: resolved,
+		}
+	}
+
+	return &MosaicResolutionStatement{
+		Height:            dto.Height.toStruct(),
+		Unresolved:        unresolved,
+		ResolutionEntries: entries,
+	}, nil
+}
+
+type blockStatementDTO struct {
+	TransactionStatements       []*transactionStatementDTO       `json:"transactionStatements"`
+	AddressResolutionStatements []*addressResolutionStatementDTO `json:"addressResolutionStatements"`
+	MosaicResolutionStatements  []*mosaicResolutionStatementDTO  `json:"mosaicResolutionStatements"`
+}
+
+func (dto *blockStatementDTO) toStruct(networkType NetworkType) (*BlockStatement, error) {
+	statement := &BlockStatement{
+		TransactionStatements:       make([]*TransactionStatement, len(dto.TransactionStatements)),
+		AddressResolutionStatements: make([]*AddressResolutionStatement, len(dto.AddressResolutionStatements)),
+		MosaicResolutionStatements:  make([]*MosaicResolutionStatement, len(dto.MosaicResolutionStatements)),
+	}
+
+	var err error
+
+	for i, s := range dto.TransactionStatements {
+		if statement.TransactionStatements[i], err = s.toStruct(networkType); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, s := range dto.AddressResolutionStatements {
+		if statement.AddressResolutionStatements[i], err = s.toStruct(); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, s := range dto.MosaicResolutionStatements {
+		if statement.MosaicResolutionStatements[i], err = s.toStruct(); err != nil {
+			return nil, err
+		}
+	}
+
+	return statement, nil
+}
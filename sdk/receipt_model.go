@@ -0,0 +1,343 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/proximax-storage/go-xpx-utils/str"
+)
+
+// ReceiptType is composed of ReceiptBasicType in the high 4 bits, index and facility code of plugin in the low byte
+type ReceiptType uint16
+
+// ReceiptType enums
+const (
+	HarvestFeeReceiptType             ReceiptType = 0x2143
+	InflationReceiptType              ReceiptType = 0x5143
+	TransactionGroupReceiptType       ReceiptType = 0xE143
+	AddressAliasResolutionReceiptType ReceiptType = 0xF143
+	MosaicAliasResolutionReceiptType  ReceiptType = 0xF243
+	MosaicLevyReceiptType             ReceiptType = 0x124D
+	MosaicRentalFeeReceiptType        ReceiptType = 0x134D
+	MosaicExpiredReceiptType          ReceiptType = 0x414D
+	NamespaceRentalFeeReceiptType     ReceiptType = 0x134E
+	NamespaceExpiredReceiptType       ReceiptType = 0x414E
+	LockHashCreatedReceiptType        ReceiptType = 0x3148
+	LockHashCompletedReceiptType      ReceiptType = 0x2248
+	LockHashExpiredReceiptType        ReceiptType = 0x2348
+	LockSecretCreatedReceiptType      ReceiptType = 0x3152
+	LockSecretCompletedReceiptType    ReceiptType = 0x2252
+	LockSecretExpiredReceiptType      ReceiptType = 0x2352
+)
+
+// ReceiptBasicType defines layout of receipt
+type ReceiptBasicType uint8
+
+// ReceiptBasicType enums
+const (
+	OtherBasicType           ReceiptBasicType = 0x0
+	BalanceTransferBasicType ReceiptBasicType = 0x1
+	BalanceCreditBasicType   ReceiptBasicType = 0x2
+	BalanceDebitBasicType    ReceiptBasicType = 0x3
+	ArtifactExpiryBasicType  ReceiptBasicType = 0x4
+	InflationBasicType       ReceiptBasicType = 0x5
+	AggregateBasicType       ReceiptBasicType = 0xE
+	AliasResolutionBasicType ReceiptBasicType = 0xF
+)
+
+func (t ReceiptType) BasicType() ReceiptBasicType {
+	return ReceiptBasicType(t >> 12)
+}
+
+func (t ReceiptType) String() string {
+	return fmt.Sprintf("%X", uint16(t))
+}
+
+type Receipt interface {
+	GetReceiptHeader() *ReceiptHeader
+	String() string
+}
+
+type ReceiptHeader struct {
+	Version uint16
+	Type    ReceiptType
+}
+
+func (r *ReceiptHeader) GetReceiptHeader() *ReceiptHeader {
+	return r
+}
+
+func (r *ReceiptHeader) String() string {
+	return str.StructToString(
+		"ReceiptHeader",
+		str.NewField("Version", str.IntPattern, r.Version),
+		str.NewField("Type", str.StringPattern, r.Type),
+	)
+}
+
+// BalanceTransferReceipt records mosaics moved from Sender to Recipient, e.g. mosaic levy or rental fee
+type BalanceTransferReceipt struct {
+	ReceiptHeader
+	Sender    *PublicAccount
+	Recipient *Address
+	MosaicId  AssetId
+	Amount    Amount
+}
+
+func (r *BalanceTransferReceipt) String() string {
+	return str.StructToString(
+		"BalanceTransferReceipt",
+		str.NewField("ReceiptHeader", str.StringPattern, r.ReceiptHeader.String()),
+		str.NewField("Sender", str.StringPattern, r.Sender),
+		str.NewField("Recipient", str.StringPattern, r.Recipient),
+		str.NewField("MosaicId", str.StringPattern, r.MosaicId),
+		str.NewField("Amount", str.StringPattern, r.Amount),
+	)
+}
+
+// BalanceChangeReceipt records mosaics credited to or debited from Account, e.g. harvest fee or lock completion
+type BalanceChangeReceipt struct {
+	ReceiptHeader
+	Account  *PublicAccount
+	MosaicId AssetId
+	Amount   Amount
+}
+
+// returns true when Amount was credited to Account
+func (r *BalanceChangeReceipt) IsCredit() bool {
+	return r.Type.BasicType() == BalanceCreditBasicType
+}
+
+func (r *BalanceChangeReceipt) String() string {
+	return str.StructToString(
+		"BalanceChangeReceipt",
+		str.NewField("ReceiptHeader", str.StringPattern, r.ReceiptHeader.String()),
+		str.NewField("Account", str.StringPattern, r.Account),
+		str.NewField("MosaicId", str.StringPattern, r.MosaicId),
+		str.NewField("Amount", str.StringPattern, r.Amount),
+	)
+}
+
+// ArtifactExpiryReceipt records expiration of mosaic or namespace
+type ArtifactExpiryReceipt struct {
+	ReceiptHeader
+	ArtifactId AssetId
+}
+
+func (r *ArtifactExpiryReceipt) String() string {
+	return str.StructToString(
+		"ArtifactExpiryReceipt",
+		str.NewField("ReceiptHeader", str.StringPattern, r.ReceiptHeader.String()),
+		str.NewField("ArtifactId", str.StringPattern, r.ArtifactId),
+	)
+}
+
+// InflationReceipt records mosaics created by the network in a block
+type InflationReceipt struct {
+	ReceiptHeader
+	MosaicId AssetId
+	Amount   Amount
+}
+
+func (r *InflationReceipt) String() string {
+	return str.StructToString(
+		"InflationReceipt",
+		str.NewField("ReceiptHeader", str.StringPattern, r.ReceiptHeader.String()),
+		str.NewField("MosaicId", str.StringPattern, r.MosaicId),
+		str.NewField("Amount", str.StringPattern, r.Amount),
+	)
+}
+
+// UnknownReceipt keeps receipts which are not known by the SDK, e.g. produced by extra plugins
+type UnknownReceipt struct {
+	ReceiptHeader
+	Raw []byte
+}
+
+func (r *UnknownReceipt) String() string {
+	return str.StructToString(
+		"UnknownReceipt",
+		str.NewField("ReceiptHeader", str.StringPattern, r.ReceiptHeader.String()),
+		str.NewField("Raw", str.StringPattern, string(r.Raw)),
+	)
+}
+
+// ReceiptSource identifies entity which produced receipt.
+// PrimaryId is index of transaction in block starting from 1, zero means block itself.
+// SecondaryId is index of inner transaction of aggregate starting from 1
+type ReceiptSource struct {
+	PrimaryId   uint32
+	SecondaryId uint32
+}
+
+// returns true when source s happened before or at other
+func (s ReceiptSource) notAfter(other ReceiptSource) bool {
+	return s.PrimaryId < other.PrimaryId || (s.PrimaryId == other.PrimaryId && s.SecondaryId <= other.SecondaryId)
+}
+
+func (s ReceiptSource) String() string {
+	return fmt.Sprintf("%d/%d", s.PrimaryId, s.SecondaryId)
+}
+
+// TransactionStatement groups receipts produced by one source
+type TransactionStatement struct {
+	Height   Height
+	Source   ReceiptSource
+	Receipts []Receipt
+}
+
+func (s *TransactionStatement) String() string {
+	return str.StructToString(
+		"TransactionStatement",
+		str.NewField("Height", str.StringPattern, s.Height),
+		str.NewField("Source", str.StringPattern, s.Source),
+		str.NewField("Receipts", str.StringPattern, s.Receipts),
+	)
+}
+
+type AddressResolutionEntry struct {
+	Source   ReceiptSource
+	Resolved *Address
+}
+
+// AddressResolutionStatement records addresses to which namespace alias was resolved in block
+type AddressResolutionStatement struct {
+	Height            Height
+	Unresolved        *Address
+	ResolutionEntries []*AddressResolutionEntry
+}
+
+func (s *AddressResolutionStatement) String() string {
+	return str.StructToString(
+		"AddressResolutionStatement",
+		str.NewField("Height", str.StringPattern, s.Height),
+		str.NewField("Unresolved", str.StringPattern, s.Unresolved),
+		str.NewField("ResolutionEntries", str.StringPattern, s.ResolutionEntries),
+	)
+}
+
+type MosaicResolutionEntry struct {
+	Source   ReceiptSource
+	Resolved *MosaicId
+}
+
+// MosaicResolutionStatement records mosaics to which namespace alias was resolved in block
+type MosaicResolutionStatement struct {
+	Height            Height
+	Unresolved        AssetId
+	ResolutionEntries []*MosaicResolutionEntry
+}
+
+func (s *MosaicResolutionStatement) String() string {
+	return str.StructToString(
+		"MosaicResolutionStatement",
+		str.NewField("Height", str.StringPattern, s.Height),
+		str.NewField("Unresolved", str.StringPattern, s.Unresolved),
+		str.NewField("ResolutionEntries", str.StringPattern, s.ResolutionEntries),
+	)
+}
+
+// BlockStatement contains all receipts and resolution statements of block
+type BlockStatement struct {
+	TransactionStatements       []*TransactionStatement
+	AddressResolutionStatements []*AddressResolutionStatement
+	MosaicResolutionStatements  []*MosaicResolutionStatement
+}
+
+func (s *BlockStatement) String() string {
+	return str.StructToString(
+		"BlockStatement",
+		str.NewField("TransactionStatements", str.StringPattern, s.TransactionStatements),
+		str.NewField("AddressResolutionStatements", str.StringPattern, s.AddressResolutionStatements),
+		str.NewField("MosaicResolutionStatements", str.StringPattern, s.MosaicResolutionStatements),
+	)
+}
+
+// returns all receipts of block with passed types. Without types all receipts are returned
+func (s *BlockStatement) Receipts(types ...ReceiptType) []Receipt {
+	receipts := make([]Receipt, 0)
+	for _, statement := range s.TransactionStatements {
+		for _, r := range statement.Receipts {
+			if len(types) == 0 || containsReceiptType(types, r.GetReceiptHeader().Type) {
+				receipts = append(receipts, r)
+			}
+		}
+	}
+
+	return receipts
+}
+
+// returns address to which unresolved address was resolved for transaction at passed source.
+// Not aliased address is returned as is
+func (s *BlockStatement) ResolveAddress(unresolved *Address, source ReceiptSource) (*Address, error) {
+	if unresolved == nil {
+		return nil, ErrNilAddress
+	}
+
+	for _, statement := range s.AddressResolutionStatements {
+		if statement.Unresolved == nil || statement.Unresolved.Address != unresolved.Address {
+			continue
+		}
+
+		var resolved *Address
+		for _, entry := range statement.ResolutionEntries {
+			if entry.Source.notAfter(source) {
+				resolved = entry.Resolved
+			}
+		}
+
+		if resolved != nil {
+			return resolved, nil
+		}
+	}
+
+	if unresolved.Type == AliasAddress {
+		return nil, ErrResolutionNotFound
+	}
+
+	return unresolved, nil
+}
+
+// returns mosaic to which unresolved asset was resolved for transaction at passed source.
+// Mosaic id is returned as is
+func (s *BlockStatement) ResolveMosaicId(unresolved AssetId, source ReceiptSource) (*MosaicId, error) {
+	if unresolved == nil {
+		return nil, ErrNilAssetId
+	}
+
+	for _, statement := range s.MosaicResolutionStatements {
+		if statement.Unresolved == nil || statement.Unresolved.Id() != unresolved.Id() {
+			continue
+		}
+
+		var resolved *MosaicId
+		for _, entry := range statement.ResolutionEntries {
+			if entry.Source.notAfter(source) {
+				resolved = entry.Resolved
+			}
+		}
+
+		if resolved != nil {
+			return resolved, nil
+		}
+	}
+
+	if mosaicId, ok := unresolved.(*MosaicId); ok {
+		return mosaicId, nil
+	}
+
+	return nil, ErrResolutionNotFound
+}
+
+func containsReceiptType(types []ReceiptType, t ReceiptType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+
+	return false
+}
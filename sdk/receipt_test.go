@@ -0,0 +1,164 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-utils/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBlockReceiptsJson = `{
+  "transactionStatements": [
+    {
+      "height": [2, 0],
+      "source": {"primaryId": 0, "secondaryId": 0},
+      "receipts": [
+        {
+          "version": 1,
+          "type": 8515,
+          "account": "CFC31B3080B36BC3D59DF4AB936AC72F4DC15CE3C3E1B1EC5EA41415A4C33FEE",
+          "mosaicId": [519256100, 642862634],
+          "amount": [100, 0]
+        },
+        {
+          "version": 1,
+          "type": 20803,
+          "mosaicId": [519256100, 642862634],
+          "amount": [50, 0]
+        }
+      ]
+    },
+    {
+      "height": [2, 0],
+      "source": {"primaryId": 1, "secondaryId": 0},
+      "receipts": [
+        {
+          "version": 1,
+          "type": 4685,
+          "sender": "CFC31B3080B36BC3D59DF4AB936AC72F4DC15CE3C3E1B1EC5EA41415A4C33FEE",
+          "recipient": "90CFA4D204CC396ED38A1BA693CB2482B58152E175BFE8B5BB",
+          "mosaicId": [519256100, 642862634],
+          "amount": [10, 0]
+        },
+        {
+          "version": 1,
+          "type": 12616,
+          "account": "CFC31B3080B36BC3D59DF4AB936AC72F4DC15CE3C3E1B1EC5EA41415A4C33FEE",
+          "mosaicId": [519256100, 642862634],
+          "amount": [10000000, 0]
+        },
+        {
+          "version": 1,
+          "type": 16717,
+          "artifactId": [519256100, 642862634]
+        },
+        {
+          "version": 1,
+          "type": 308
+        }
+      ]
+    }
+  ],
+  "addressResolutionStatements": [
+    {
+      "height": [2, 0],
+      "unresolved": "91F6BD1691A142FBBF00000000000000000000000000000000",
+      "resolutionEntries": [
+        {"source": {"primaryId": 1, "secondaryId": 0}, "resolved": "90CFA4D204CC396ED38A1BA693CB2482B58152E175BFE8B5BB"}
+      ]
+    }
+  ],
+  "mosaicResolutionStatements": [
+    {
+      "height": [2, 0],
+      "unresolved": [2434186742, 3220914849],
+      "resolutionEntries": [
+        {"source": {"primaryId": 1, "secondaryId": 0}, "resolved": [519256100, 642862634]}
+      ]
+    }
+  ]
+}`
+
+func TestReceiptService_GetBlockReceipts(t *testing.T) {
+	mock := newSdkMockWithRouter(&mock.Router{
+		Path:                fmt.Sprintf(blockReceiptsRoute, Height(2)),
+		AcceptedHttpMethods: []string{http.MethodGet},
+		RespHttpCode:        200,
+		RespBody:            testBlockReceiptsJson,
+	})
+	receiptClient := mock.getPublicTestClientUnsafe().Receipt
+
+	defer mock.Close()
+
+	statement, err := receiptClient.GetBlockReceipts(ctx, 2)
+	assert.Nil(t, err)
+	assert.Len(t, statement.TransactionStatements, 2)
+
+	assert.Equal(t, &BalanceChangeReceipt{
+		ReceiptHeader: ReceiptHeader{Version: 1, Type: HarvestFeeReceiptType},
+		Account:       testLockAccount,
+		MosaicId:      testLockMosaicId,
+		Amount:        100,
+	}, statement.TransactionStatements[0].Receipts[0])
+	assert.True(t, statement.TransactionStatements[0].Receipts[0].(*BalanceChangeReceipt).IsCredit())
+
+	assert.Equal(t, &InflationReceipt{
+		ReceiptHeader: ReceiptHeader{Version: 1, Type: InflationReceiptType},
+		MosaicId:      testLockMosaicId,
+		Amount:        50,
+	}, statement.TransactionStatements[0].Receipts[1])
+
+	assert.Equal(t, ReceiptSource{PrimaryId: 1}, statement.TransactionStatements[1].Source)
+	assert.Equal(t, &BalanceTransferReceipt{
+		ReceiptHeader: ReceiptHeader{Version: 1, Type: MosaicLevyReceiptType},
+		Sender:        testLockAccount,
+		Recipient:     testRecipientAddress,
+		MosaicId:      testLockMosaicId,
+		Amount:        10,
+	}, statement.TransactionStatements[1].Receipts[0])
+	assert.False(t, statement.TransactionStatements[1].Receipts[1].(*BalanceChangeReceipt).IsCredit())
+	assert.Equal(t, &ArtifactExpiryReceipt{
+		ReceiptHeader: ReceiptHeader{Version: 1, Type: MosaicExpiredReceiptType},
+		ArtifactId:    testLockMosaicId,
+	}, statement.TransactionStatements[1].Receipts[2])
+	assert.IsType(t, &UnknownReceipt{}, statement.TransactionStatements[1].Receipts[3])
+
+	assert.Len(t, statement.Receipts(HarvestFeeReceiptType, MosaicLevyReceiptType), 2)
+	assert.Len(t, statement.Receipts(), 6)
+
+	alias := statement.AddressResolutionStatements[0].Unresolved
+	_, err = statement.ResolveAddress(alias, ReceiptSource{})
+	assert.Equal(t, ErrResolutionNotFound, err)
+	resolved, err := statement.ResolveAddress(alias, ReceiptSource{PrimaryId: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, testRecipientAddress, resolved)
+	resolved, err = statement.ResolveAddress(testRecipientAddress, ReceiptSource{})
+	assert.Nil(t, err)
+	assert.Equal(t, testRecipientAddress, resolved)
+
+	mosaicId, err := statement.ResolveMosaicId(XpxNamespaceId, ReceiptSource{PrimaryId: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, testLockMosaicId, mosaicId)
+}
+
+func TestReceiptService_GetReceiptMerklePath(t *testing.T) {
+	leaf, sibling := &Hash{1}, &Hash{2}
+	block := &BlockInfo{BlockReceiptsHash: testMerkleHash(sibling, leaf)}
+
+	mock := newSdkMockWithRouter(&mock.Router{
+		Path:                fmt.Sprintf(blockReceiptMerkleRoute, Height(2), leaf),
+		AcceptedHttpMethods: []string{http.MethodGet},
+		RespHttpCode:        200,
+		RespBody:            fmt.Sprintf(`{"merklePath": [{"position": "left", "hash": "%s"}]}`, sibling),
+	})
+	receiptClient := mock.getPublicTestClientUnsafe().Receipt
+
+	defer mock.Close()
+
+	path, err := receiptClient.GetReceiptMerklePath(ctx, 2, leaf)
+	assert.Nil(t, err)
+	assert.Nil(t, VerifyReceiptMerklePath(leaf, path, block))
+	assert.Equal(t, ErrMerkleRootMismatch, VerifyReceiptMerklePath(sibling, path, block))
+}
@@ -0,0 +1,277 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// AccountPropertiesSource is the part of AccountService used by RestrictionChecker
+type AccountPropertiesSource interface {
+	GetAccountsProperties(ctx context.Context, addresses ...*Address) ([]*AccountProperties, error)
+}
+
+type RestrictionRule uint8
+
+const (
+	// Recipient blocks incoming transactions from sender
+	RecipientBlocksSender RestrictionRule = iota
+	// Recipient allows incoming transactions only from other addresses
+	RecipientNotAllowsSender
+	// Recipient blocks incoming mosaic
+	RecipientBlocksMosaic
+	// Recipient allows only other incoming mosaics
+	RecipientNotAllowsMosaic
+	// Sender blocks outgoing transactions of entity type
+	SenderBlocksEntityType
+	// Sender allows only outgoing transactions of other entity types
+	SenderNotAllowsEntityType
+)
+
+func (r RestrictionRule) String() string {
+	switch r {
+	case RecipientBlocksSender:
+		return "recipient blocks sender address"
+	case RecipientNotAllowsSender:
+		return "recipient doesn't allow sender address"
+	case RecipientBlocksMosaic:
+		return "recipient blocks mosaic"
+	case RecipientNotAllowsMosaic:
+		return "recipient doesn't allow mosaic"
+	case SenderBlocksEntityType:
+		return "sender blocks entity type"
+	case SenderNotAllowsEntityType:
+		return "sender doesn't allow entity type"
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(r))
+}
+
+// RestrictionViolation is one account property which makes the node reject transaction
+type RestrictionViolation struct {
+	Rule RestrictionRule
+	// Account which owns violated property
+	Account      *Address
+	PropertyType PropertyType
+	// *Address, *MosaicId or EntityType which is blocked or not allowed
+	Value interface{}
+}
+
+func (v *RestrictionViolation) Error() string {
+	return fmt.Sprintf("%s: account %s, property type %d, value %v", v.Rule, v.Account.Address, v.PropertyType, v.Value)
+}
+
+// RestrictionReport contains every violation of account properties by transfer
+type RestrictionReport struct {
+	Sender     *Address
+	Recipient  *Address
+	Violations []*RestrictionViolation
+	// True when recipient is namespace alias, so its properties aren't checked
+	UncheckedRecipient bool
+	// Mosaics referenced by namespace aliases, properties of recipient aren't checked for them
+	UncheckedMosaics []AssetId
+}
+
+// returns true when transfer doesn't violate any checked property
+func (r *RestrictionReport) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// RestrictionChecker checks transfers against account properties of sender and recipient before announcing.
+// Properties are cached per address, so changed properties are seen only after Invalidate or Reset
+type RestrictionChecker struct {
+	sync.RWMutex
+	source     AccountPropertiesSource
+	properties map[string]*AccountProperties
+}
+
+// returns new RestrictionChecker requesting properties from source
+func NewRestrictionChecker(source AccountPropertiesSource) *RestrictionChecker {
+	return &RestrictionChecker{
+		source:     source,
+		properties: make(map[string]*AccountProperties),
+	}
+}
+
+// returns new RestrictionChecker requesting properties from REST
+func (c *Client) NewRestrictionChecker() *RestrictionChecker {
+	return NewRestrictionChecker(c.Account)
+}
+
+// CheckTransfer returns every property of sender and recipient which transfer violates.
+// Sender is signer of transfer and it is used when transfer isn't signed yet
+func (r *RestrictionChecker) CheckTransfer(ctx context.Context, sender *PublicAccount, tx *TransferTransaction) (*RestrictionReport, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	if tx.Signer != nil {
+		sender = tx.Signer
+	}
+
+	if sender == nil {
+		return nil, ErrNilAccount
+	}
+
+	if tx.Recipient == nil {
+		return nil, ErrNilAddress
+	}
+
+	report := &RestrictionReport{
+		Sender:             sender.Address,
+		Recipient:          tx.Recipient,
+		Violations:         make([]*RestrictionViolation, 0),
+		UncheckedRecipient: tx.Recipient.Type == AliasAddress,
+		UncheckedMosaics:   make([]AssetId, 0),
+	}
+
+	addresses := []*Address{sender.Address}
+	if !report.UncheckedRecipient {
+		addresses = append(addresses, tx.Recipient)
+	}
+
+	properties, err := r.accountsProperties(ctx, addresses...)
+	if err != nil {
+		return nil, err
+	}
+
+	senderProperties := properties[sender.Address.Address]
+	report.Violations = append(report.Violations, entityTypeViolations(senderProperties, tx.Type)...)
+
+	if report.UncheckedRecipient {
+		return report, nil
+	}
+
+	recipientProperties := properties[tx.Recipient.Address]
+	report.Violations = append(report.Violations, addressViolations(recipientProperties, sender.Address)...)
+
+	for _, m := range tx.Mosaics {
+		if m.AssetId.Type() != MosaicAssetIdType {
+			report.UncheckedMosaics = append(report.UncheckedMosaics, m.AssetId)
+			continue
+		}
+
+		report.Violations = append(report.Violations, mosaicViolations(recipientProperties, m.AssetId)...)
+	}
+
+	return report, nil
+}
+
+// Invalidate removes cached properties of addresses
+func (r *RestrictionChecker) Invalidate(addresses ...*Address) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, a := range addresses {
+		delete(r.properties, a.Address)
+	}
+}
+
+// Reset removes all cached properties
+func (r *RestrictionChecker) Reset() {
+	r.Lock()
+	defer r.Unlock()
+
+	r.properties = make(map[string]*AccountProperties)
+}
+
+// returns properties of addresses by address, requesting only those which aren't cached.
+// Accounts without properties get properties without values
+func (r *RestrictionChecker) accountsProperties(ctx context.Context, addresses ...*Address) (map[string]*AccountProperties, error) {
+	result := make(map[string]*AccountProperties, len(addresses))
+	missing := make([]*Address, 0, len(addresses))
+
+	r.RLock()
+	for _, a := range addresses {
+		if p, ok := r.properties[a.Address]; ok {
+			result[a.Address] = p
+		} else {
+			missing = append(missing, a)
+		}
+	}
+	r.RUnlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	requested, err := r.source.GetAccountsProperties(ctx, missing...)
+	if err != nil && err != ErrResourceNotFound {
+		return nil, err
+	}
+
+	for _, a := range missing {
+		result[a.Address] = &AccountProperties{Address: a}
+	}
+
+	for _, p := range requested {
+		if p.Address != nil {
+			result[p.Address.Address] = p
+		}
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	for _, a := range missing {
+		r.properties[a.Address] = result[a.Address]
+	}
+
+	return result, nil
+}
+
+func addressViolations(properties *AccountProperties, sender *Address) []*RestrictionViolation {
+	for _, a := range properties.BlockedAddresses {
+		if a.Address == sender.Address {
+			return []*RestrictionViolation{{RecipientBlocksSender, properties.Address, BlockAddress, sender}}
+		}
+	}
+
+	if len(properties.AllowedAddresses) == 0 {
+		return nil
+	}
+
+	for _, a := range properties.AllowedAddresses {
+		if a.Address == sender.Address {
+			return nil
+		}
+	}
+
+	return []*RestrictionViolation{{RecipientNotAllowsSender, properties.Address, AllowAddress, sender}}
+}
+
+func mosaicViolations(properties *AccountProperties, assetId AssetId) []*RestrictionViolation {
+	for _, m := range properties.BlockedMosaicId {
+		if m.Id() == assetId.Id() {
+			return []*RestrictionViolation{{RecipientBlocksMosaic, properties.Address, BlockMosaic, assetId}}
+		}
+	}
+
+	if len(properties.AllowedMosaicId) == 0 {
+		return nil
+	}
+
+	for _, m := range properties.AllowedMosaicId {
+		if m.Id() == assetId.Id() {
+			return nil
+		}
+	}
+
+	return []*RestrictionViolation{{RecipientNotAllowsMosaic, properties.Address, AllowMosaic, assetId}}
+}
+
+func entityTypeViolations(properties *AccountProperties, entityType EntityType) []*RestrictionViolation {
+	if containsEntityType(properties.BlockedEntityTypes, entityType) {
+		return []*RestrictionViolation{{SenderBlocksEntityType, properties.Address, BlockTransaction, entityType}}
+	}
+
+	if len(properties.AllowedEntityTypes) > 0 && !containsEntityType(properties.AllowedEntityTypes, entityType) {
+		return []*RestrictionViolation{{SenderNotAllowsEntityType, properties.Address, AllowTransaction, entityType}}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAccountPropertiesSource struct {
+	properties map[string]*AccountProperties
+	requested  [][]*Address
+}
+
+func (s *fakeAccountPropertiesSource) GetAccountsProperties(_ context.Context, addresses ...*Address) ([]*AccountProperties, error) {
+	s.requested = append(s.requested, addresses)
+
+	result := make([]*AccountProperties, 0)
+	for _, a := range addresses {
+		if p, ok := s.properties[a.Address]; ok {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+func TestRestrictionChecker_CheckTransfer(t *testing.T) {
+	sender := testMultisigOutsider(t).PublicAccount
+	recipient := newAddressFromRaw(nemTestAddress1)
+	blocked, allowed := newMosaicIdPanic(1), newMosaicIdPanic(2)
+
+	source := &fakeAccountPropertiesSource{properties: map[string]*AccountProperties{
+		sender.Address.Address: {
+			Address:            sender.Address,
+			AllowedEntityTypes: []EntityType{AccountPropertyEntityType},
+		},
+		recipient.Address: {
+			Address:          recipient,
+			BlockedAddresses: []*Address{sender.Address},
+			BlockedMosaicId:  []*MosaicId{blocked},
+		},
+	}}
+	checker := NewRestrictionChecker(source)
+
+	tx, err := NewTransferTransaction(fakeDeadline, recipient, []*Mosaic{
+		newMosaicPanic(blocked, 1),
+		newMosaicPanic(allowed, 1),
+		newMosaicPanic(XpxNamespaceId, 1),
+	}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	report, err := checker.CheckTransfer(ctx, sender, tx)
+	assert.Nil(t, err)
+	assert.False(t, report.Passed())
+	assert.Equal(t, []*RestrictionViolation{
+		{SenderNotAllowsEntityType, sender.Address, AllowTransaction, Transfer},
+		{RecipientBlocksSender, recipient, BlockAddress, sender.Address},
+		{RecipientBlocksMosaic, recipient, BlockMosaic, blocked},
+	}, report.Violations)
+	assert.Equal(t, []AssetId{XpxNamespaceId}, report.UncheckedMosaics)
+
+	// properties are cached
+	_, err = checker.CheckTransfer(ctx, sender, tx)
+	assert.Nil(t, err)
+	assert.Len(t, source.requested, 1)
+
+	checker.Invalidate(recipient)
+	source.properties[recipient.Address] = &AccountProperties{
+		Address:          recipient,
+		AllowedAddresses: []*Address{newAddressFromRaw(nemTestAddress2)},
+		AllowedMosaicId:  []*MosaicId{allowed},
+	}
+	delete(source.properties, sender.Address.Address)
+
+	report, err = checker.CheckTransfer(ctx, sender, tx)
+	assert.Nil(t, err)
+	assert.Equal(t, [][]*Address{{sender.Address, recipient}, {recipient}}, source.requested)
+	assert.Equal(t, []*RestrictionViolation{
+		{SenderNotAllowsEntityType, sender.Address, AllowTransaction, Transfer},
+		{RecipientNotAllowsSender, recipient, AllowAddress, sender.Address},
+		{RecipientNotAllowsMosaic, recipient, AllowMosaic, blocked},
+	}, report.Violations)
+
+	// account without properties doesn't restrict anything
+	checker.Reset()
+	report, err = checker.CheckTransfer(ctx, sender, tx)
+	assert.Nil(t, err)
+	assert.Len(t, report.Violations, 2)
+	assert.Equal(t, RecipientNotAllowsSender, report.Violations[0].Rule)
+}
+
+func TestRestrictionChecker_CheckTransfer_AliasRecipient(t *testing.T) {
+	sender := testMultisigOutsider(t).PublicAccount
+	recipient, err := NewAddressFromNamespace(XpxNamespaceId)
+	assert.Nil(t, err)
+
+	source := &fakeAccountPropertiesSource{}
+	tx, err := NewTransferTransaction(fakeDeadline, recipient, []*Mosaic{}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	report, err := NewRestrictionChecker(source).CheckTransfer(ctx, sender, tx)
+	assert.Nil(t, err)
+	assert.True(t, report.Passed())
+	assert.True(t, report.UncheckedRecipient)
+	assert.Equal(t, [][]*Address{{sender.Address}}, source.requested)
+}
@@ -8,17 +8,18 @@ package sdk
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
 	"github.com/json-iterator/go"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -151,7 +152,10 @@ func NewConfigWithReputation(
 type Client struct {
 	client *http.Client // HTTP client used to communicate with the API.
 	config *Config
-	common service // Reuse a single struct instead of allocating one for each service on the heap.
+	// Versions of entities supported by network, nil means that versions of the SDK are used
+	supportedEntities     *SupportedEntities
+	supportedEntitiesLock sync.RWMutex
+	common                service // Reuse a single struct instead of allocating one for each service on the heap.
 	// Services for communicating to the Catapult REST APIs
 	Blockchain    *BlockchainService
 	Exchange      *ExchangeService
@@ -167,6 +171,7 @@ type Client struct {
 	Lock          *LockService
 	Contract      *ContractService
 	Metadata      *MetadataService
+	Receipt       *ReceiptService
 }
 
 type service struct {
@@ -209,10 +214,20 @@ func NewClient(httpClient *http.Client, conf *Config) *Client {
 	c.SuperContract = (*SuperContractService)(&c.common)
 	c.Contract = (*ContractService)(&c.common)
 	c.Metadata = (*MetadataService)(&c.common)
+	c.Receipt = (*ReceiptService)(&c.common)
 
 	return c
 }
 
+// returns client with the same config which requests only passed node
+func (c *Client) nodeClient(u url.URL) *Client {
+	conf := *c.config
+	conf.BaseURLs = []url.URL{u}
+	conf.UsedBaseUrl = u
+
+	return NewClient(c.client, &conf)
+}
+
 func (c *Client) NetworkType() NetworkType {
 	return c.config.NetworkType
 }
@@ -228,13 +243,12 @@ func (c *Client) BlockGenerationTime(ctx context.Context) (time.Duration, error)
 		return 0, err
 	}
 
-	if pl, ok := cfg.NetworkConfig.Sections["chain"]; ok {
-		if v, ok := pl.Fields["blockGenerationTargetTime"]; ok {
-			return time.ParseDuration(v.Value)
-		}
+	d, err := cfg.NetworkConfig.BlockGenerationTargetTime()
+	if isConfigNotFound(err) {
+		return defaultBlockGenerationTime, nil
 	}
 
-	return time.Second * 15, nil
+	return d, err
 }
 
 // AdaptAccount returns a new account with the same network type and generation hash like a Client
@@ -373,112 +387,208 @@ func min(a, b int) int {
 	return b
 }
 
-func (c *Client) modifyTransaction(tx Transaction) {
+func (c *Client) modifyTransaction(tx Transaction) error {
+	if err := c.adaptEntityVersion(tx.GetAbstractTransaction()); err != nil {
+		return err
+	}
+
 	// We don't change MaxFee for versioning transactions
 	switch tx.GetAbstractTransaction().Type {
 	case NetworkConfigEntityType, BlockchainUpgrade:
 	default:
 		tx.GetAbstractTransaction().MaxFee = Amount(min(tx.Size()*int(c.config.FeeCalculationStrategy), DefaultMaxFee))
 	}
+
+	return nil
+}
+
+// SetSupportedEntities makes transaction constructors of client pick the highest version of entity
+// which is both serializable by the SDK and supported by network. Nil restores versions of the SDK
+func (c *Client) SetSupportedEntities(entities *SupportedEntities) {
+	c.supportedEntitiesLock.Lock()
+	defer c.supportedEntitiesLock.Unlock()
+
+	c.supportedEntities = entities
+}
+
+// SyncSupportedEntities requests versions of entities supported by network at the current height
+// and makes transaction constructors of client use them. It should be called again after chain upgrade
+func (c *Client) SyncSupportedEntities(ctx context.Context) error {
+	config, err := c.Network.GetNetworkConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.SetSupportedEntities(config.SupportedEntityVersions)
+
+	return nil
+}
+
+// returns ErrEntityVersionUnsupported when network supports none of versions the SDK can serialize
+func (c *Client) adaptEntityVersion(tx *AbstractTransaction) error {
+	c.supportedEntitiesLock.RLock()
+	defer c.supportedEntitiesLock.RUnlock()
+
+	if c.supportedEntities == nil {
+		return nil
+	}
+
+	versions, ok := serializableEntityVersions[tx.Type]
+	if !ok {
+		versions = []EntityVersion{tx.Version}
+	}
+
+	v, ok := c.supportedEntities.HighestVersion(tx.Type, versions)
+	if !ok {
+		return errors.Wrapf(ErrEntityVersionUnsupported, "%s versions %v", tx.Type, versions)
+	}
+
+	tx.Version = v
+
+	return nil
 }
 
 func (c *Client) NewAddressAliasTransaction(deadline *Deadline, address *Address, namespaceId *NamespaceId, actionType AliasActionType) (*AddressAliasTransaction, error) {
 	tx, err := NewAddressAliasTransaction(deadline, address, namespaceId, actionType, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewMosaicAliasTransaction(deadline *Deadline, mosaicId *MosaicId, namespaceId *NamespaceId, actionType AliasActionType) (*MosaicAliasTransaction, error) {
 	tx, err := NewMosaicAliasTransaction(deadline, mosaicId, namespaceId, actionType, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewAccountLinkTransaction(deadline *Deadline, remoteAccount *PublicAccount, linkAction AccountLinkAction) (*AccountLinkTransaction, error) {
 	tx, err := NewAccountLinkTransaction(deadline, remoteAccount, linkAction, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewAccountPropertiesAddressTransaction(deadline *Deadline, propertyType PropertyType, modifications []*AccountPropertiesAddressModification) (*AccountPropertiesAddressTransaction, error) {
 	tx, err := NewAccountPropertiesAddressTransaction(deadline, propertyType, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewAccountPropertiesMosaicTransaction(deadline *Deadline, propertyType PropertyType, modifications []*AccountPropertiesMosaicModification) (*AccountPropertiesMosaicTransaction, error) {
 	tx, err := NewAccountPropertiesMosaicTransaction(deadline, propertyType, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewAccountPropertiesEntityTypeTransaction(deadline *Deadline, propertyType PropertyType, modifications []*AccountPropertiesEntityTypeModification) (*AccountPropertiesEntityTypeTransaction, error) {
 	tx, err := NewAccountPropertiesEntityTypeTransaction(deadline, propertyType, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewAddExchangeOfferTransaction(deadline *Deadline, addOffers []*AddOffer) (*AddExchangeOfferTransaction, error) {
 	tx, err := NewAddExchangeOfferTransaction(deadline, addOffers, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewExchangeOfferTransaction(deadline *Deadline, confirmations []*ExchangeConfirmation) (*ExchangeOfferTransaction, error) {
 	tx, err := NewExchangeOfferTransaction(deadline, confirmations, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewRemoveExchangeOfferTransaction(deadline *Deadline, removeOffers []*RemoveOffer) (*RemoveExchangeOfferTransaction, error) {
 	tx, err := NewRemoveExchangeOfferTransaction(deadline, removeOffers, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewNetworkConfigTransaction(deadline *Deadline, delta Duration, config *NetworkConfig, entities *SupportedEntities) (*NetworkConfigTransaction, error) {
 	tx, err := NewNetworkConfigTransaction(deadline, delta, config, entities, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewBlockchainUpgradeTransaction(deadline *Deadline, upgradePeriod Duration, newBlockChainVersion BlockChainVersion) (*BlockchainUpgradeTransaction, error) {
 	tx, err := NewBlockchainUpgradeTransaction(deadline, upgradePeriod, newBlockChainVersion, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewCompleteAggregateTransaction(deadline *Deadline, innerTxs []Transaction) (*AggregateTransaction, error) {
@@ -486,9 +596,16 @@ func (c *Client) NewCompleteAggregateTransaction(deadline *Deadline, innerTxs []
 	if err != nil {
 		return nil, err
 	}
-	c.modifyTransaction(tx)
 
-	return tx, tx.UpdateUniqueAggregateHash(c.config.GenerationHash)
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	if err = tx.UpdateUniqueAggregateHash(c.config.GenerationHash); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewBondedAggregateTransaction(deadline *Deadline, innerTxs []Transaction) (*AggregateTransaction, error) {
@@ -496,45 +613,68 @@ func (c *Client) NewBondedAggregateTransaction(deadline *Deadline, innerTxs []Tr
 	if err != nil {
 		return nil, err
 	}
-	c.modifyTransaction(tx)
 
-	return tx, tx.UpdateUniqueAggregateHash(c.config.GenerationHash)
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	if err = tx.UpdateUniqueAggregateHash(c.config.GenerationHash); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewModifyMetadataAddressTransaction(deadline *Deadline, address *Address, modifications []*MetadataModification) (*ModifyMetadataAddressTransaction, error) {
 	tx, err := NewModifyMetadataAddressTransaction(deadline, address, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewModifyMetadataMosaicTransaction(deadline *Deadline, mosaicId *MosaicId, modifications []*MetadataModification) (*ModifyMetadataMosaicTransaction, error) {
 	tx, err := NewModifyMetadataMosaicTransaction(deadline, mosaicId, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewModifyMetadataNamespaceTransaction(deadline *Deadline, namespaceId *NamespaceId, modifications []*MetadataModification) (*ModifyMetadataNamespaceTransaction, error) {
 	tx, err := NewModifyMetadataNamespaceTransaction(deadline, namespaceId, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewModifyMultisigAccountTransaction(deadline *Deadline, minApprovalDelta int8, minRemovalDelta int8, modifications []*MultisigCosignatoryModification) (*ModifyMultisigAccountTransaction, error) {
 	tx, err := NewModifyMultisigAccountTransaction(deadline, minApprovalDelta, minRemovalDelta, modifications, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewModifyContractTransaction(
@@ -543,249 +683,357 @@ func (c *Client) NewModifyContractTransaction(
 	executors []*MultisigCosignatoryModification,
 	verifiers []*MultisigCosignatoryModification) (*ModifyContractTransaction, error) {
 	tx, err := NewModifyContractTransaction(deadline, durationDelta, hash, customers, executors, verifiers, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewMosaicDefinitionTransaction(deadline *Deadline, nonce uint32, ownerPublicKey string, mosaicProps *MosaicProperties) (*MosaicDefinitionTransaction, error) {
 	tx, err := NewMosaicDefinitionTransaction(deadline, nonce, ownerPublicKey, mosaicProps, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewMosaicSupplyChangeTransaction(deadline *Deadline, assetId AssetId, supplyType MosaicSupplyType, delta Duration) (*MosaicSupplyChangeTransaction, error) {
 	tx, err := NewMosaicSupplyChangeTransaction(deadline, assetId, supplyType, delta, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewTransferTransaction(deadline *Deadline, recipient *Address, mosaics []*Mosaic, message Message) (*TransferTransaction, error) {
 	tx, err := NewTransferTransaction(deadline, recipient, mosaics, message, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewTransferTransactionWithNamespace(deadline *Deadline, recipient *NamespaceId, mosaics []*Mosaic, message Message) (*TransferTransaction, error) {
 	tx, err := NewTransferTransactionWithNamespace(deadline, recipient, mosaics, message, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewRegisterRootNamespaceTransaction(deadline *Deadline, namespaceName string, duration Duration) (*RegisterNamespaceTransaction, error) {
 	tx, err := NewRegisterRootNamespaceTransaction(deadline, namespaceName, duration, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewRegisterSubNamespaceTransaction(deadline *Deadline, namespaceName string, parentId *NamespaceId) (*RegisterNamespaceTransaction, error) {
 	tx, err := NewRegisterSubNamespaceTransaction(deadline, namespaceName, parentId, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewLockFundsTransaction(deadline *Deadline, mosaic *Mosaic, duration Duration, signedTx *SignedTransaction) (*LockFundsTransaction, error) {
 	tx, err := NewLockFundsTransaction(deadline, mosaic, duration, signedTx, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewSecretLockTransaction(deadline *Deadline, mosaic *Mosaic, duration Duration, secret *Secret, recipient *Address) (*SecretLockTransaction, error) {
 	tx, err := NewSecretLockTransaction(deadline, mosaic, duration, secret, recipient, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewSecretProofTransaction(deadline *Deadline, hashType HashType, proof *Proof, recipient *Address) (*SecretProofTransaction, error) {
 	tx, err := NewSecretProofTransaction(deadline, hashType, proof, recipient, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 func (c *Client) NewPrepareDriveTransaction(deadline *Deadline, owner *PublicAccount,
 	duration Duration, billingPeriod Duration, billingPrice Amount, driveSize StorageSize,
 	replicas uint16, minReplicators uint16, percentApprovers uint8) (*PrepareDriveTransaction, error) {
 
 	tx, err := NewPrepareDriveTransaction(deadline, owner, duration, billingPeriod, billingPrice, driveSize, replicas, minReplicators, percentApprovers, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewJoinToDriveTransaction(deadline *Deadline, driveKey *PublicAccount) (*JoinToDriveTransaction, error) {
 	tx, err := NewJoinToDriveTransaction(deadline, driveKey, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewDriveFileSystemTransaction(deadline *Deadline, driveKey string, newRootHash *Hash, oldRootHash *Hash, addActions []*Action, removeActions []*Action) (*DriveFileSystemTransaction, error) {
 	tx, err := NewDriveFileSystemTransaction(deadline, driveKey, newRootHash, oldRootHash, addActions, removeActions, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewFilesDepositTransaction(deadline *Deadline, driveKey *PublicAccount, files []*File) (*FilesDepositTransaction, error) {
 	tx, err := NewFilesDepositTransaction(deadline, driveKey, files, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewEndDriveTransaction(deadline *Deadline, driveKey *PublicAccount) (*EndDriveTransaction, error) {
 	tx, err := NewEndDriveTransaction(deadline, driveKey, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewDriveFilesRewardTransaction(deadline *Deadline, uploadInfos []*UploadInfo) (*DriveFilesRewardTransaction, error) {
 	tx, err := NewDriveFilesRewardTransaction(deadline, uploadInfos, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewStartDriveVerificationTransaction(deadline *Deadline, driveKey *PublicAccount) (*StartDriveVerificationTransaction, error) {
 	tx, err := NewStartDriveVerificationTransaction(deadline, driveKey, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewEndDriveVerificationTransaction(deadline *Deadline, failures []*FailureVerification) (*EndDriveVerificationTransaction, error) {
 	tx, err := NewEndDriveVerificationTransaction(deadline, failures, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewDeployTransaction(deadline *Deadline, drive, owner *PublicAccount, fileHash *Hash, vmVersion uint64) (*DeployTransaction, error) {
 	tx, err := NewDeployTransaction(deadline, drive, owner, fileHash, vmVersion, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewStartExecuteTransaction(deadline *Deadline, supercontract *PublicAccount, mosaics []*Mosaic,
 	function string, functionParameters []int64) (*StartExecuteTransaction, error) {
 
 	tx, err := NewStartExecuteTransaction(deadline, supercontract, mosaics, function, functionParameters, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewEndExecuteTransaction(deadline *Deadline, mosaics []*Mosaic, token *Hash, status OperationStatus) (*EndExecuteTransaction, error) {
 	tx, err := NewEndExecuteTransaction(deadline, mosaics, token, status, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewOperationIdentifyTransaction(deadline *Deadline, hash *Hash) (*OperationIdentifyTransaction, error) {
 	tx, err := NewOperationIdentifyTransaction(deadline, hash, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewEndOperationTransaction(deadline *Deadline, mosaics []*Mosaic, token *Hash, status OperationStatus) (*EndOperationTransaction, error) {
 	tx, err := NewEndOperationTransaction(deadline, mosaics, token, status, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewStartFileDownloadTransaction(deadline *Deadline, drive *PublicAccount, files []*DownloadFile) (*StartFileDownloadTransaction, error) {
 	tx, err := NewStartFileDownloadTransaction(deadline, drive, files, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewEndFileDownloadTransaction(deadline *Deadline, recipient *PublicAccount, operationToken *Hash, files []*DownloadFile) (*EndFileDownloadTransaction, error) {
 	tx, err := NewEndFileDownloadTransaction(deadline, recipient, operationToken, files, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func (c *Client) NewSuperContractFileSystemTransaction(deadline *Deadline, driveKey string, newRootHash *Hash, oldRootHash *Hash, addActions []*Action, removeActions []*Action) (*SuperContractFileSystemTransaction, error) {
 	tx, err := NewSuperContractFileSystemTransaction(deadline, driveKey, newRootHash, oldRootHash, addActions, removeActions, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
 }
 
 func (c *Client) NewDeactivateTransaction(deadline *Deadline, sc string, driveKey string) (*DeactivateTransaction, error) {
 	tx, err := NewDeactivateTransaction(deadline, sc, driveKey, c.config.NetworkType)
-	if tx != nil {
-		c.modifyTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.modifyTransaction(tx); err != nil {
+		return nil, err
 	}
 
-	return tx, err
+	return tx, nil
 }
 
 func addOptions(s string, opt interface{}) (string, error) {
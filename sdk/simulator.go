@@ -0,0 +1,287 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"fmt"
+	"math"
+)
+
+type EffectKind uint8
+
+const (
+	// Fee paid by signer of transaction
+	FeeEffect EffectKind = iota
+	// Mosaics moved from signer to recipient
+	TransferEffect
+	// Mosaics locked by hash lock, secret lock, exchange offer or supercontract execution
+	EscrowEffect
+	// Mosaics exchanged with owner of offer
+	ExchangeEffect
+	// Supply of mosaic changed by its owner
+	SupplyEffect
+)
+
+func (k EffectKind) String() string {
+	switch k {
+	case FeeEffect:
+		return "fee"
+	case TransferEffect:
+		return "transfer"
+	case EscrowEffect:
+		return "escrow"
+	case ExchangeEffect:
+		return "exchange"
+	case SupplyEffect:
+		return "supply"
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(k))
+}
+
+// EffectDependency is a set of reasons why effect can differ at execution
+type EffectDependency uint8
+
+const (
+	// Asset id or address is namespace alias which is resolved at execution
+	DependsOnAlias EffectDependency = 1 << iota
+	// Max fee is only upper bound of fee, which depends on fee multiplier of block
+	DependsOnFeeMultiplier
+	// Exchange is settled only when offer still exists with the same price at execution
+	DependsOnOffer
+)
+
+// BalanceEffect is a change of balance of one mosaic of one account made by one transaction
+type BalanceEffect struct {
+	Address *Address
+	AssetId AssetId
+	// Negative for debits
+	Amount      int64
+	Kind        EffectKind
+	Dependency  EffectDependency
+	Transaction Transaction
+}
+
+// returns true when effect doesn't depend on chain state
+func (e *BalanceEffect) Exact() bool {
+	return e.Dependency == 0
+}
+
+// BalanceDelta is a sum of effects on balance of one mosaic of one account
+type BalanceDelta struct {
+	Address *Address
+	AssetId AssetId
+	// Negative when account sends more than receives
+	Amount int64
+	// Union of dependencies of summed effects
+	Dependency EffectDependency
+}
+
+// Simulation is expected outcome of transaction for balances
+type Simulation struct {
+	Effects []*BalanceEffect
+	// Sums of effects in order of first effect. Asset ids are not resolved,
+	// so alias and mosaic id of the same mosaic have separate deltas
+	Deltas []*BalanceDelta
+	// Transactions which change balances by amounts known only to the node, e.g. secret proofs, rental fees or drive deposits
+	Unsimulated []Transaction
+}
+
+// returns deltas of address in order of first effect
+func (s *Simulation) DeltasOf(address *Address) []*BalanceDelta {
+	deltas := make([]*BalanceDelta, 0)
+	for _, d := range s.Deltas {
+		if d.Address.Address == address.Address {
+			deltas = append(deltas, d)
+		}
+	}
+
+	return deltas
+}
+
+// SimulateTransaction returns expected balance effects of transaction including every inner transaction of aggregate.
+// Signer is used for transactions which aren't signed yet. Max fee and exchange costs are paid in passed currency mosaic,
+// e.g. NetworkConfig.CurrencyMosaicId. The node isn't requested, so effects which depend on chain state are marked
+// with EffectDependency. Amounts and their sums which don't fit into int64 give ErrAmountOverflow
+func SimulateTransaction(signer *PublicAccount, tx Transaction, currency *MosaicId) (*Simulation, error) {
+	if tx == nil {
+		return nil, ErrNilTransaction
+	}
+
+	if currency == nil {
+		return nil, ErrNilMosaicId
+	}
+
+	if txSigner := tx.GetAbstractTransaction().Signer; txSigner != nil {
+		signer = txSigner
+	}
+
+	if signer == nil {
+		return nil, ErrNilAccount
+	}
+
+	s := &simulator{
+		simulation: &Simulation{
+			Effects:     make([]*BalanceEffect, 0),
+			Deltas:      make([]*BalanceDelta, 0),
+			Unsimulated: make([]Transaction, 0),
+		},
+		deltas:   make(map[string]*BalanceDelta),
+		currency: currency,
+	}
+
+	if fee := tx.GetAbstractTransaction().MaxFee; fee > 0 {
+		if err := s.add(tx, signer.Address, newMosaicPanic(currency, fee), -1, FeeEffect, DependsOnFeeMultiplier); err != nil {
+			return nil, err
+		}
+	}
+
+	inner := []Transaction{tx}
+	if aggregate, ok := tx.(*AggregateTransaction); ok {
+		inner = aggregate.InnerTransactions
+	}
+
+	for _, t := range inner {
+		innerSigner := t.GetAbstractTransaction().Signer
+		if innerSigner == nil {
+			innerSigner = signer
+		}
+
+		if err := s.simulate(innerSigner, t); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.simulation, nil
+}
+
+type simulator struct {
+	simulation *Simulation
+	deltas     map[string]*BalanceDelta
+	currency   *MosaicId
+}
+
+func (s *simulator) simulate(signer *PublicAccount, tx Transaction) error {
+	switch t := tx.(type) {
+	case *TransferTransaction:
+		if t.Recipient == nil {
+			return ErrNilAddress
+		}
+
+		var dependency EffectDependency
+		if t.Recipient.Type == AliasAddress {
+			dependency = DependsOnAlias
+		}
+
+		for _, m := range t.Mosaics {
+			if err := s.add(tx, signer.Address, m, -1, TransferEffect, 0); err != nil {
+				return err
+			}
+
+			if err := s.add(tx, t.Recipient, m, 1, TransferEffect, dependency); err != nil {
+				return err
+			}
+		}
+	case *LockFundsTransaction:
+		if err := s.add(tx, signer.Address, t.Mosaic, -1, EscrowEffect, 0); err != nil {
+			return err
+		}
+	case *SecretLockTransaction:
+		if err := s.add(tx, signer.Address, t.Mosaic, -1, EscrowEffect, 0); err != nil {
+			return err
+		}
+	case *StartExecuteTransaction:
+		for _, m := range t.LockMosaics {
+			if err := s.add(tx, signer.Address, m, -1, EscrowEffect, 0); err != nil {
+				return err
+			}
+		}
+	case *AddExchangeOfferTransaction:
+		for _, o := range t.Offers {
+			if err := s.add(tx, signer.Address, offerDeposit(&o.Offer, o.Type, s.currency), -1, EscrowEffect, 0); err != nil {
+				return err
+			}
+		}
+	case *ExchangeOfferTransaction:
+		for _, c := range t.Confirmations {
+			if c.Owner == nil {
+				return ErrNilAccount
+			}
+
+			// deposit of owner was escrowed by offer, so owner only receives what signer pays
+			paid := offerDeposit(&c.Offer, c.Type.CounterOffer(), s.currency)
+			received := offerDeposit(&c.Offer, c.Type, s.currency)
+			if err := s.add(tx, signer.Address, paid, -1, ExchangeEffect, DependsOnOffer); err != nil {
+				return err
+			}
+
+			if err := s.add(tx, signer.Address, received, 1, ExchangeEffect, DependsOnOffer); err != nil {
+				return err
+			}
+
+			if err := s.add(tx, c.Owner.Address, paid, 1, ExchangeEffect, DependsOnOffer); err != nil {
+				return err
+			}
+		}
+	case *MosaicSupplyChangeTransaction:
+		sign := int64(1)
+		if t.MosaicSupplyType == Decrease {
+			sign = -1
+		}
+
+		if err := s.add(tx, signer.Address, newMosaicPanic(t.AssetId, t.Delta), sign, SupplyEffect, 0); err != nil {
+			return err
+		}
+	case *SecretProofTransaction, *RemoveExchangeOfferTransaction, *RegisterNamespaceTransaction,
+		*MosaicDefinitionTransaction, *JoinToDriveTransaction, *FilesDepositTransaction, *EndDriveTransaction:
+		s.simulation.Unsimulated = append(s.simulation.Unsimulated, tx)
+	}
+
+	return nil
+}
+
+// adds effect of mosaic multiplied by sign
+func (s *simulator) add(tx Transaction, address *Address, mosaic *Mosaic, sign int64, kind EffectKind, dependency EffectDependency) error {
+	if mosaic == nil || mosaic.AssetId == nil {
+		return ErrNilAssetId
+	}
+
+	// amount greater than MaxInt64 wraps to negative one
+	if mosaic.Amount < 0 {
+		return ErrAmountOverflow
+	}
+
+	if mosaic.AssetId.Type() == NamespaceAssetIdType {
+		dependency |= DependsOnAlias
+	}
+
+	effect := &BalanceEffect{
+		Address:     address,
+		AssetId:     mosaic.AssetId,
+		Amount:      sign * int64(mosaic.Amount),
+		Kind:        kind,
+		Dependency:  dependency,
+		Transaction: tx,
+	}
+
+	key := fmt.Sprintf("%s/%d/%d", address.Address, mosaic.AssetId.Type(), mosaic.AssetId.Id())
+	delta, ok := s.deltas[key]
+	if ok && (effect.Amount > 0 && delta.Amount > math.MaxInt64-effect.Amount ||
+		effect.Amount < 0 && delta.Amount < math.MinInt64-effect.Amount) {
+		return ErrAmountOverflow
+	}
+
+	s.simulation.Effects = append(s.simulation.Effects, effect)
+	if !ok {
+		delta = &BalanceDelta{Address: address, AssetId: mosaic.AssetId}
+		s.deltas[key] = delta
+		s.simulation.Deltas = append(s.simulation.Deltas, delta)
+	}
+
+	delta.Amount += effect.Amount
+	delta.Dependency |= effect.Dependency
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package sdk
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	signer := testMultisigOutsider(t).PublicAccount
+	owner := newTestTreasury(t).a.PublicAccount
+	storage := newMosaicIdPanic(1)
+
+	transfer, err := NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{newMosaicPanic(storage, 10)}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	exchange, err := NewExchangeOfferTransaction(fakeDeadline, []*ExchangeConfirmation{
+		{Offer{SellOffer, newMosaicPanic(storage, 5), 50}, owner},
+	}, MijinTest)
+	assert.Nil(t, err)
+
+	proof, err := NewSecretProofTransaction(fakeDeadline, SHA3_256, &Proof{[]byte{1}}, testRecipientAddress, MijinTest)
+	assert.Nil(t, err)
+
+	aggregate, err := NewCompleteAggregateTransaction(fakeDeadline, []Transaction{transfer, exchange, proof}, MijinTest)
+	assert.Nil(t, err)
+	aggregate.MaxFee = 25
+
+	simulation, err := SimulateTransaction(signer, aggregate, testCurrencyMosaicId)
+	assert.Nil(t, err)
+	assert.Len(t, simulation.Effects, 6)
+	assert.Equal(t, []Transaction{proof}, simulation.Unsimulated)
+
+	fee := simulation.Effects[0]
+	assert.Equal(t, FeeEffect, fee.Kind)
+	assert.Equal(t, int64(-25), fee.Amount)
+	assert.Equal(t, DependsOnFeeMultiplier, fee.Dependency)
+	assert.True(t, simulation.Effects[1].Exact())
+
+	assert.Equal(t, []*BalanceDelta{
+		{signer.Address, testCurrencyMosaicId, -75, DependsOnFeeMultiplier | DependsOnOffer},
+		{signer.Address, storage, -5, DependsOnOffer},
+	}, simulation.DeltasOf(signer.Address))
+	assert.Equal(t, []*BalanceDelta{{testRecipientAddress, storage, 10, 0}}, simulation.DeltasOf(testRecipientAddress))
+	// mosaics sold by owner were escrowed by offer, so owner only receives cost
+	assert.Equal(t, []*BalanceDelta{{owner.Address, testCurrencyMosaicId, 50, DependsOnOffer}}, simulation.DeltasOf(owner.Address))
+}
+
+func TestSimulateTransaction_Escrow(t *testing.T) {
+	signer := testMultisigOutsider(t).PublicAccount
+	recipient, err := NewAddressFromNamespace(XpxNamespaceId)
+	assert.Nil(t, err)
+
+	secretLock, err := NewSecretLockTransaction(fakeDeadline, newMosaicPanic(newMosaicIdPanic(1), 10), 100, &Secret{Type: SHA3_256}, testRecipientAddress, MijinTest)
+	assert.Nil(t, err)
+
+	simulation, err := SimulateTransaction(signer, secretLock, testCurrencyMosaicId)
+	assert.Nil(t, err)
+	assert.Equal(t, EscrowEffect, simulation.Effects[0].Kind)
+	assert.Equal(t, int64(-10), simulation.Effects[0].Amount)
+	assert.Empty(t, simulation.DeltasOf(testRecipientAddress))
+
+	transfer, err := NewTransferTransaction(fakeDeadline, recipient, []*Mosaic{newMosaicPanic(newMosaicIdPanic(1), 10)}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	simulation, err = SimulateTransaction(signer, transfer, testCurrencyMosaicId)
+	assert.Nil(t, err)
+	assert.Equal(t, DependsOnAlias, simulation.DeltasOf(recipient)[0].Dependency)
+
+	_, err = SimulateTransaction(nil, transfer, testCurrencyMosaicId)
+	assert.Equal(t, ErrNilAccount, err)
+
+	_, err = SimulateTransaction(signer, transfer, nil)
+	assert.Equal(t, ErrNilMosaicId, err)
+}
+
+func TestSimulateTransaction_Overflow(t *testing.T) {
+	signer := testMultisigOutsider(t).PublicAccount
+	storage := newMosaicIdPanic(1)
+
+	transfer, err := NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{Xpx(math.MaxUint64)}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	_, err = SimulateTransaction(signer, transfer, testCurrencyMosaicId)
+	assert.Equal(t, ErrAmountOverflow, err)
+
+	// each amount fits, but their sum doesn't
+	transfer, err = NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{
+		newMosaicPanic(storage, math.MaxInt64),
+		newMosaicPanic(storage, 1),
+	}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+
+	_, err = SimulateTransaction(signer, transfer, testCurrencyMosaicId)
+	assert.Equal(t, ErrAmountOverflow, err)
+}
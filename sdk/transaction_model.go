@@ -3471,6 +3471,54 @@ const (
 	DeactivateVersion                EntityVersion = 1
 )
 
+// versions of every entity which the SDK can serialize, client picks the highest one supported by network.
+// New layouts of an entity should be added here together with their serialization
+var serializableEntityVersions = map[EntityType][]EntityVersion{
+	AccountPropertyAddress:    {AccountPropertyAddressVersion},
+	AccountPropertyMosaic:     {AccountPropertyMosaicVersion},
+	AccountPropertyEntityType: {AccountPropertyEntityTypeVersion},
+	AddressAlias:              {AddressAliasVersion},
+	AggregateBonded:           {AggregateBondedVersion},
+	AggregateCompleted:        {AggregateCompletedVersion},
+	AddExchangeOffer:          {AddExchangeOfferVersion},
+	ExchangeOffer:             {ExchangeOfferVersion},
+	RemoveExchangeOffer:       {RemoveExchangeOfferVersion},
+	NetworkConfigEntityType:   {NetworkConfigVersion},
+	BlockchainUpgrade:         {BlockchainUpgradeVersion},
+	LinkAccount:               {LinkAccountVersion},
+	Lock:                      {LockVersion},
+	MetadataAddress:           {MetadataAddressVersion},
+	MetadataMosaic:            {MetadataMosaicVersion},
+	MetadataNamespace:         {MetadataNamespaceVersion},
+	ModifyContract:            {ModifyContractVersion},
+	ModifyMultisig:            {ModifyMultisigVersion},
+	MosaicAlias:               {MosaicAliasVersion},
+	MosaicDefinition:          {MosaicDefinitionVersion},
+	MosaicSupplyChange:        {MosaicSupplyChangeVersion},
+	RegisterNamespace:         {RegisterNamespaceVersion},
+	SecretLock:                {SecretLockVersion},
+	SecretProof:               {SecretProofVersion},
+	Transfer:                  {TransferVersion},
+	PrepareDrive:              {PrepareDriveVersion},
+	JoinToDrive:               {JoinToDriveVersion},
+	DriveFileSystem:           {DriveFileSystemVersion},
+	FilesDeposit:              {FilesDepositVersion},
+	EndDrive:                  {EndDriveVersion},
+	DriveFilesReward:          {DriveFilesRewardVersion},
+	StartDriveVerification:    {StartDriveVerificationVersion},
+	EndDriveVerification:      {EndDriveVerificationVersion},
+	StartFileDownload:         {StartFileDownloadVersion},
+	EndFileDownload:           {EndFileDownloadVersion},
+	Deploy:                    {DeployVersion},
+	StartExecute:              {StartExecuteVersion},
+	EndExecute:                {EndExecuteVersion},
+	StartOperation:            {StartOperationVersion},
+	EndOperation:              {EndOperationVersion},
+	OperationIdentify:         {OperationIdentifyVersion},
+	SuperContractFileSystem:   {SuperContractFileSystemVersion},
+	Deactivate:                {DeactivateVersion},
+}
+
 type AccountLinkAction uint8
 
 // AccountLinkAction enums
@@ -3794,6 +3842,43 @@ func signTransactionWith(tx Transaction, a *Account) (*SignedTransaction, error)
 	return &SignedTransaction{tx.GetAbstractTransaction().Type, strings.ToUpper(hex.EncodeToString(p)), h}, nil
 }
 
+// returns hash of signed transaction from its signature, signer and serialized body,
+// e.g. to check hash of transaction received from node
+func CalculateTransactionHash(tx Transaction, generationHash *Hash) (*Hash, error) {
+	abs := tx.GetAbstractTransaction()
+	if abs.Signer == nil {
+		return nil, ErrNilAccount
+	}
+
+	signature, err := hex.DecodeString(abs.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signature) != SignatureSize {
+		return nil, ErrInvalidSignatureLength
+	}
+
+	signer, err := hex.DecodeString(abs.Signer.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(signer) != SignerSize {
+		return nil, ErrInvalidSignerLength
+	}
+
+	b, err := tx.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	copy(b[SizeSize:SizeSize+SignatureSize], signature)
+	copy(b[SizeSize+SignatureSize:SizeSize+SignatureSize+SignerSize], signer)
+
+	return createTransactionHash(b, generationHash)
+}
+
 func InnerTransactionHash(tx Transaction) *Hash {
 	b, err := toAggregateTransactionBytes(tx)
 	if err != nil {
@@ -974,3 +974,34 @@ func TestMapTransactions(t *testing.T) {
 	assert.Nilf(t, err, "MapTransaction returned error: %s", err)
 	assert.True(t, len(txs) == 2)
 }
+
+func TestCalculateTransactionHash(t *testing.T) {
+	account, err := NewAccount(MijinTest, &Hash{1})
+	assert.Nil(t, err)
+
+	transfer, err := NewTransferTransaction(fakeDeadline, testRecipientAddress, []*Mosaic{Xpx(10)}, NewPlainMessage(""), MijinTest)
+	assert.Nil(t, err)
+	transfer.Signer = account.PublicAccount
+
+	tx, err := NewBondedAggregateTransaction(fakeDeadline, []Transaction{transfer}, MijinTest)
+	assert.Nil(t, err)
+
+	signed, err := account.Sign(tx)
+	assert.Nil(t, err)
+
+	// signature follows size of transaction in payload
+	tx.Signature = signed.Payload[2*SizeSize : 2*(SizeSize+SignatureSize)]
+	tx.Signer = account.PublicAccount
+
+	hash, err := CalculateTransactionHash(tx, &Hash{1})
+	assert.Nil(t, err)
+	assert.Equal(t, signed.Hash, hash)
+
+	hash, err = CalculateTransactionHash(tx, &Hash{2})
+	assert.Nil(t, err)
+	assert.NotEqual(t, signed.Hash, hash)
+
+	tx.Signature = ""
+	_, err = CalculateTransactionHash(tx, &Hash{1})
+	assert.Equal(t, ErrInvalidSignatureLength, err)
+}
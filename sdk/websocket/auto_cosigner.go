@@ -0,0 +1,390 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+var (
+	ErrNoCosignRules      = errors.New("auto cosigner should have at least one rule")
+	ErrNoCosignAccounts   = errors.New("auto cosigner should have at least one account")
+	ErrNoGenerationHash   = errors.New("auto cosigner should have generation hash of network")
+	ErrCosignRuleFailed   = errors.New("aggregate doesn't satisfy cosign rule")
+	ErrPartialHashIsNil   = errors.New("partial aggregate doesn't have hash")
+	ErrPartialHashInvalid = errors.New("hash of partial aggregate doesn't match its content")
+	ErrAlreadyCosigned    = errors.New("aggregate is already cosigned by account")
+	ErrSignedByCosigner   = errors.New("aggregate is signed by cosigner itself")
+	ErrCosignaturePresent = errors.New("aggregate already contains cosignature of account")
+)
+
+// CosignRule checks partial aggregate before cosigner signs it. Returned error explains rejection
+type CosignRule func(cosigner *sdk.PublicAccount, tx *sdk.AggregateTransaction) error
+
+// CosignatureAnnouncer is the part of sdk.TransactionService used by AutoCosigner
+type CosignatureAnnouncer interface {
+	AnnounceAggregateBondedCosignature(ctx context.Context, c *sdk.CosignatureSignedTransaction) (string, error)
+}
+
+type partialAddedAdder interface {
+	AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error
+}
+
+type CosignOutcome uint8
+
+const (
+	// Aggregate is cosigned and cosignature is announced
+	CosignApproved CosignOutcome = iota
+	// Aggregate doesn't satisfy rules
+	CosignRejected
+	// Aggregate doesn't need cosignature of account, e.g. it was already cosigned
+	CosignSkipped
+	// Aggregate satisfies rules, but signing or announcing failed
+	CosignFailed
+)
+
+func (o CosignOutcome) String() string {
+	switch o {
+	case CosignApproved:
+		return "approved"
+	case CosignRejected:
+		return "rejected"
+	case CosignSkipped:
+		return "skipped"
+	case CosignFailed:
+		return "failed"
+	}
+
+	return fmt.Sprintf("unknown(%d)", uint8(o))
+}
+
+// CosignDecision is a record of audit log about one partial aggregate received by one cosigner
+type CosignDecision struct {
+	Time      time.Time
+	Cosigner  *sdk.PublicAccount
+	Hash      *sdk.Hash
+	Aggregate *sdk.AggregateTransaction
+	Outcome   CosignOutcome
+	// Reason of rejection, skipping or failure
+	Err error
+}
+
+// CosignAuditLog records every decision of AutoCosigner. Record can be called concurrently
+type CosignAuditLog interface {
+	Record(decision *CosignDecision)
+}
+
+// CosignedHashes remembers aggregates cosigned by accounts. It can be persistent to survive restarts
+type CosignedHashes interface {
+	// MarkCosigned returns false when hash was already marked for cosigner. It can be called concurrently
+	MarkCosigned(cosigner *sdk.PublicAccount, hash *sdk.Hash) bool
+}
+
+type AutoCosignerConfig struct {
+	// Generation hash of network, used to check that hash received from node is the hash of signed aggregate
+	GenerationHash *sdk.Hash
+	// Every rule should pass to cosign aggregate
+	Rules []CosignRule
+	// Audit log of decisions. Nil means MemoryCosignAuditLog
+	Audit CosignAuditLog
+	// Nil means in-memory set, so aggregates are cosigned once per process
+	Cosigned CosignedHashes
+}
+
+// AutoCosigner cosigns partial aggregates of its accounts which satisfy all rules.
+// Every aggregate is cosigned by every account at most once, even if announcing fails
+type AutoCosigner struct {
+	ws        partialAddedAdder
+	announcer CosignatureAnnouncer
+	accounts  []*sdk.Account
+	config    AutoCosignerConfig
+}
+
+// returns AutoCosigner of passed accounts. Passed websocket client should be listened by caller
+func NewAutoCosigner(ws CatapultClient, announcer CosignatureAnnouncer, cfg AutoCosignerConfig, accounts ...*sdk.Account) *AutoCosigner {
+	return newAutoCosigner(ws, announcer, cfg, accounts...)
+}
+
+func newAutoCosigner(ws partialAddedAdder, announcer CosignatureAnnouncer, cfg AutoCosignerConfig, accounts ...*sdk.Account) *AutoCosigner {
+	if cfg.Audit == nil {
+		cfg.Audit = NewMemoryCosignAuditLog()
+	}
+
+	if cfg.Cosigned == nil {
+		cfg.Cosigned = newMemoryCosignedHashes()
+	}
+
+	return &AutoCosigner{
+		ws:        ws,
+		announcer: announcer,
+		accounts:  accounts,
+		config:    cfg,
+	}
+}
+
+// returns audit log of decisions
+func (c *AutoCosigner) Audit() CosignAuditLog {
+	return c.config.Audit
+}
+
+// Start subscribes to partial aggregates of every account. Subscriptions are removed when ctx is done
+func (c *AutoCosigner) Start(ctx context.Context) error {
+	if len(c.config.Rules) == 0 {
+		return ErrNoCosignRules
+	}
+
+	if len(c.accounts) == 0 {
+		return ErrNoCosignAccounts
+	}
+
+	if c.config.GenerationHash == nil {
+		return ErrNoGenerationHash
+	}
+
+	for _, account := range c.accounts {
+		account := account
+		err := c.ws.AddPartialAddedHandlers(account.PublicAccount.Address, func(tx *sdk.AggregateTransaction) bool {
+			if ctx.Err() != nil {
+				return true
+			}
+
+			c.Cosign(ctx, account, tx)
+			return false
+		})
+		if err != nil {
+			return errors.Wrapf(err, "subscribing to partials of %s", account.PublicAccount.Address.Address)
+		}
+	}
+
+	return nil
+}
+
+// Cosign evaluates rules for aggregate and cosigns it by account when they pass. Decision is recorded to audit log
+func (c *AutoCosigner) Cosign(ctx context.Context, account *sdk.Account, tx *sdk.AggregateTransaction) *CosignDecision {
+	decision := c.decide(ctx, account, tx)
+	c.config.Audit.Record(decision)
+
+	return decision
+}
+
+func (c *AutoCosigner) decide(ctx context.Context, account *sdk.Account, tx *sdk.AggregateTransaction) *CosignDecision {
+	cosigner := account.PublicAccount
+	decision := &CosignDecision{
+		Time:      time.Now(),
+		Cosigner:  cosigner,
+		Hash:      tx.TransactionInfo.TransactionHash,
+		Aggregate: tx,
+		Outcome:   CosignSkipped,
+	}
+
+	if decision.Hash == nil {
+		decision.Outcome, decision.Err = CosignRejected, ErrPartialHashIsNil
+		return decision
+	}
+
+	// cosignature signs the hash, so it should be the hash of aggregate which rules are checked for
+	hash, err := sdk.CalculateTransactionHash(tx, c.config.GenerationHash)
+	if err != nil {
+		decision.Outcome, decision.Err = CosignRejected, errors.Wrapf(ErrPartialHashInvalid, "calculating hash: %s", err)
+		return decision
+	}
+
+	if !hash.Equal(decision.Hash) {
+		decision.Outcome, decision.Err = CosignRejected, ErrPartialHashInvalid
+		return decision
+	}
+
+	if tx.Signer != nil && tx.Signer.PublicKey == cosigner.PublicKey {
+		decision.Err = ErrSignedByCosigner
+		return decision
+	}
+
+	for _, c := range tx.Cosignatures {
+		if c.Signer != nil && c.Signer.PublicKey == cosigner.PublicKey {
+			decision.Err = ErrCosignaturePresent
+			return decision
+		}
+	}
+
+	for _, rule := range c.config.Rules {
+		if err := rule(cosigner, tx); err != nil {
+			decision.Outcome, decision.Err = CosignRejected, err
+			return decision
+		}
+	}
+
+	// hash is marked before signing, so concurrent deliveries of the same aggregate can't be signed twice
+	if !c.config.Cosigned.MarkCosigned(cosigner, decision.Hash) {
+		decision.Err = ErrAlreadyCosigned
+		return decision
+	}
+
+	decision.Outcome = CosignFailed
+
+	cosignatureTx, err := sdk.NewCosignatureTransaction(tx)
+	if err != nil {
+		decision.Err = err
+		return decision
+	}
+
+	signed, err := account.SignCosignatureTransaction(cosignatureTx)
+	if err != nil {
+		decision.Err = errors.Wrap(err, "signing cosignature")
+		return decision
+	}
+
+	if _, err = c.announcer.AnnounceAggregateBondedCosignature(ctx, signed); err != nil {
+		decision.Err = errors.Wrap(err, "announcing cosignature")
+		return decision
+	}
+
+	decision.Outcome = CosignApproved
+	return decision
+}
+
+// returns rule which passes when every inner transaction satisfies filter. Aggregate without inner transactions doesn't pass
+func RequireEveryInner(filter TransactionFilter, description string) CosignRule {
+	return func(_ *sdk.PublicAccount, tx *sdk.AggregateTransaction) error {
+		if len(tx.InnerTransactions) == 0 {
+			return errors.Wrap(ErrCosignRuleFailed, "aggregate doesn't have inner transactions")
+		}
+
+		for i, inner := range tx.InnerTransactions {
+			if !filter(inner) {
+				return errors.Wrapf(ErrCosignRuleFailed, "inner transaction %d: %s", i, description)
+			}
+		}
+
+		return nil
+	}
+}
+
+// returns rule which passes when every inner transaction has one of passed types
+func AllowEntityTypes(types ...sdk.EntityType) CosignRule {
+	return RequireEveryInner(FilterByEntityType(types...), fmt.Sprintf("type should be one of %v", types))
+}
+
+// returns rule which passes when every inner transaction sends to one of passed addresses.
+// Inner transactions without recipient, e.g. ModifyMultisigAccountTransaction, don't pass
+func AllowRecipients(recipients ...*sdk.Address) CosignRule {
+	allowed := make(map[string]bool, len(recipients))
+	for _, r := range recipients {
+		allowed[r.Address] = true
+	}
+
+	return RequireEveryInner(func(tx sdk.Transaction) bool {
+		r := transactionRecipient(tx)
+		return r != nil && allowed[r.Address]
+	}, "recipient is not allowed")
+}
+
+// MosaicAliasResolver returns mosaic linked to namespace, e.g. by sdk.NamespaceService.GetLinkedMosaicId
+type MosaicAliasResolver func(namespaceId *sdk.NamespaceId) (*sdk.MosaicId, error)
+
+// returns rule which passes when inner transactions carry at most passed amount of mosaic in total.
+// Negative amounts don't pass. Namespace aliases of inner mosaics are resolved by passed resolver,
+// when it is nil inner mosaics with alias don't pass, because they can hide the limited mosaic
+func MaxTotalAmount(mosaicId *sdk.MosaicId, max sdk.Amount, resolve MosaicAliasResolver) CosignRule {
+	return func(_ *sdk.PublicAccount, tx *sdk.AggregateTransaction) error {
+		var total sdk.Amount
+		for _, inner := range tx.InnerTransactions {
+			for _, m := range transactionMosaics(inner) {
+				assetId := m.AssetId
+				if namespaceId, ok := assetId.(*sdk.NamespaceId); ok {
+					if resolve == nil {
+						return errors.Wrapf(ErrCosignRuleFailed, "alias %s of mosaic is not resolved", namespaceId)
+					}
+
+					resolved, err := resolve(namespaceId)
+					if err != nil {
+						return errors.Wrapf(ErrCosignRuleFailed, "resolving alias %s: %s", namespaceId, err)
+					}
+
+					if resolved == nil {
+						return errors.Wrapf(ErrCosignRuleFailed, "alias %s is not linked to mosaic", namespaceId)
+					}
+
+					assetId = resolved
+				}
+
+				if !sameAssetId(assetId, mosaicId) {
+					continue
+				}
+
+				if m.Amount < 0 {
+					return errors.Wrapf(ErrCosignRuleFailed, "amount %s of %s is negative", m.Amount, mosaicId)
+				}
+
+				// total never exceeds max here, so the difference doesn't overflow unlike the sum
+				if m.Amount > max-total {
+					return errors.Wrapf(ErrCosignRuleFailed, "total amount of %s is greater than %s", mosaicId, max)
+				}
+				total += m.Amount
+			}
+		}
+
+		return nil
+	}
+}
+
+// MemoryCosignAuditLog keeps decisions in memory
+type MemoryCosignAuditLog struct {
+	sync.RWMutex
+	decisions []*CosignDecision
+}
+
+func NewMemoryCosignAuditLog() *MemoryCosignAuditLog {
+	return &MemoryCosignAuditLog{decisions: make([]*CosignDecision, 0)}
+}
+
+func (l *MemoryCosignAuditLog) Record(decision *CosignDecision) {
+	l.Lock()
+	defer l.Unlock()
+
+	l.decisions = append(l.decisions, decision)
+}
+
+// returns recorded decisions in order of recording
+func (l *MemoryCosignAuditLog) Decisions() []*CosignDecision {
+	l.RLock()
+	defer l.RUnlock()
+
+	return append([]*CosignDecision{}, l.decisions...)
+}
+
+type memoryCosignedHashes struct {
+	sync.Mutex
+	cosigned map[string]map[sdk.Hash]bool
+}
+
+func newMemoryCosignedHashes() *memoryCosignedHashes {
+	return &memoryCosignedHashes{cosigned: make(map[string]map[sdk.Hash]bool)}
+}
+
+func (h *memoryCosignedHashes) MarkCosigned(cosigner *sdk.PublicAccount, hash *sdk.Hash) bool {
+	h.Lock()
+	defer h.Unlock()
+
+	hashes, ok := h.cosigned[cosigner.PublicKey]
+	if !ok {
+		hashes = make(map[sdk.Hash]bool)
+		h.cosigned[cosigner.PublicKey] = hashes
+	}
+
+	if hashes[*hash] {
+		return false
+	}
+	hashes[*hash] = true
+
+	return true
+}
@@ -0,0 +1,257 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+type fakeCosignatureAnnouncer struct {
+	sync.Mutex
+	announced []*sdk.CosignatureSignedTransaction
+	err       error
+}
+
+func (a *fakeCosignatureAnnouncer) AnnounceAggregateBondedCosignature(_ context.Context, c *sdk.CosignatureSignedTransaction) (string, error) {
+	a.Lock()
+	defer a.Unlock()
+
+	if a.err != nil {
+		return "", a.err
+	}
+
+	a.announced = append(a.announced, c)
+	return "ok", nil
+}
+
+type fakePartialAddedAdder struct {
+	handlers map[string]subscribers.PartialAddedHandler
+}
+
+func (a *fakePartialAddedAdder) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
+	a.handlers[address.Address] = handlers[0]
+	return nil
+}
+
+var (
+	testCosignGenerationHash = &sdk.Hash{1}
+	testCosignDeadline       = sdk.NewDeadline(time.Hour)
+	testCosignXpxId, _       = sdk.NewMosaicId(0x0dc67fbe1cad29e3)
+)
+
+// resolves only alias of xpx
+func testResolveXpx(namespaceId *sdk.NamespaceId) (*sdk.MosaicId, error) {
+	if namespaceId.Id() == sdk.XpxNamespaceId.Id() {
+		return testCosignXpxId, nil
+	}
+
+	return nil, errors.New("namespace is not linked")
+}
+
+// returns partial aggregate signed by initiator with hash as it is received from node
+func testCosignPartial(t *testing.T, initiator *sdk.Account, recipient *sdk.Address, amount sdk.Amount) *sdk.AggregateTransaction {
+	tx, err := sdk.NewBondedAggregateTransaction(testCosignDeadline, []sdk.Transaction{newFilterTransfer(t, recipient, amount)}, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	signed, err := initiator.Sign(tx)
+	assert.Nil(t, err)
+
+	// signature follows size of transaction in payload
+	tx.Signature = signed.Payload[2*sdk.SizeSize : 2*(sdk.SizeSize+sdk.SignatureSize)]
+	tx.Signer = initiator.PublicAccount
+	tx.TransactionInfo.TransactionHash = signed.Hash
+
+	return tx
+}
+
+func TestAutoCosigner(t *testing.T) {
+	cosigner, err := sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nil(t, err)
+	allowed, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+	other, err := sdk.NewAccountFromPublicKey(filterSignerKey, sdk.MijinTest)
+	assert.Nil(t, err)
+	initiator, err := sdk.NewAccount(sdk.MijinTest, testCosignGenerationHash)
+	assert.Nil(t, err)
+
+	ws := &fakePartialAddedAdder{handlers: make(map[string]subscribers.PartialAddedHandler)}
+	announcer := &fakeCosignatureAnnouncer{}
+	audit := NewMemoryCosignAuditLog()
+
+	autoCosigner := newAutoCosigner(ws, announcer, AutoCosignerConfig{
+		Rules: []CosignRule{
+			AllowEntityTypes(sdk.Transfer),
+			AllowRecipients(allowed.Address),
+			MaxTotalAmount(testCosignXpxId, 100, testResolveXpx),
+		},
+		Audit:          audit,
+		GenerationHash: testCosignGenerationHash,
+	}, cosigner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.Nil(t, autoCosigner.Start(ctx))
+
+	handler := ws.handlers[cosigner.PublicAccount.Address.Address]
+	assert.NotNil(t, handler)
+
+	partial := testCosignPartial(t, initiator, allowed.Address, 100)
+	assert.False(t, handler(partial))
+	assert.Len(t, announcer.announced, 1)
+	assert.Equal(t, partial.TransactionInfo.TransactionHash, announcer.announced[0].ParentHash)
+
+	// the same hash is never signed twice
+	assert.False(t, handler(testCosignPartial(t, initiator, allowed.Address, 100)))
+	assert.Len(t, announcer.announced, 1)
+
+	assert.False(t, handler(testCosignPartial(t, initiator, other.Address, 10)))
+	assert.False(t, handler(testCosignPartial(t, initiator, allowed.Address, 101)))
+	assert.Len(t, announcer.announced, 1)
+
+	announcer.err = errors.New("node is down")
+	assert.False(t, handler(testCosignPartial(t, initiator, allowed.Address, 1)))
+
+	decisions := audit.Decisions()
+	assert.Len(t, decisions, 5)
+	assert.Equal(t, CosignApproved, decisions[0].Outcome)
+	assert.Equal(t, CosignSkipped, decisions[1].Outcome)
+	assert.Equal(t, ErrAlreadyCosigned, decisions[1].Err)
+	assert.Equal(t, CosignRejected, decisions[2].Outcome)
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(decisions[2].Err))
+	assert.Equal(t, CosignRejected, decisions[3].Outcome)
+	assert.Equal(t, CosignFailed, decisions[4].Outcome)
+
+	// failed hash isn't retried
+	announcer.err = nil
+	assert.Equal(t, CosignSkipped, autoCosigner.Cosign(ctx, cosigner, testCosignPartial(t, initiator, allowed.Address, 1)).Outcome)
+
+	cancel()
+	assert.True(t, handler(testCosignPartial(t, initiator, allowed.Address, 2)))
+	assert.Len(t, audit.Decisions(), 6)
+}
+
+func TestAutoCosigner_Start(t *testing.T) {
+	ws := &fakePartialAddedAdder{handlers: make(map[string]subscribers.PartialAddedHandler)}
+
+	err := newAutoCosigner(ws, &fakeCosignatureAnnouncer{}, AutoCosignerConfig{}).Start(context.Background())
+	assert.Equal(t, ErrNoCosignRules, err)
+
+	err = newAutoCosigner(ws, &fakeCosignatureAnnouncer{}, AutoCosignerConfig{Rules: []CosignRule{AllowEntityTypes(sdk.Transfer)}}).Start(context.Background())
+	assert.Equal(t, ErrNoCosignAccounts, err)
+
+	cosigner, err := sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nil(t, err)
+	err = newAutoCosigner(ws, &fakeCosignatureAnnouncer{}, AutoCosignerConfig{Rules: []CosignRule{AllowEntityTypes(sdk.Transfer)}}, cosigner).Start(context.Background())
+	assert.Equal(t, ErrNoGenerationHash, err)
+}
+
+func TestAutoCosigner_TamperedHash(t *testing.T) {
+	cosigner, err := sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nil(t, err)
+	initiator, err := sdk.NewAccount(sdk.MijinTest, testCosignGenerationHash)
+	assert.Nil(t, err)
+	recipient, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	announcer := &fakeCosignatureAnnouncer{}
+	audit := NewMemoryCosignAuditLog()
+	autoCosigner := newAutoCosigner(nil, announcer, AutoCosignerConfig{
+		Rules:          []CosignRule{MaxTotalAmount(testCosignXpxId, 100, testResolveXpx)},
+		Audit:          audit,
+		GenerationHash: testCosignGenerationHash,
+	}, cosigner)
+
+	// node sends hash of another aggregate together with content which passes rules
+	partial := testCosignPartial(t, initiator, recipient.Address, 10)
+	partial.TransactionInfo.TransactionHash = testCosignPartial(t, initiator, recipient.Address, 1000).TransactionInfo.TransactionHash
+
+	decision := autoCosigner.Cosign(context.Background(), cosigner, partial)
+	assert.Equal(t, CosignRejected, decision.Outcome)
+	assert.Equal(t, ErrPartialHashInvalid, decision.Err)
+
+	partial.Signature = ""
+	decision = autoCosigner.Cosign(context.Background(), cosigner, partial)
+	assert.Equal(t, CosignRejected, decision.Outcome)
+	assert.Equal(t, ErrPartialHashInvalid, errors.Cause(decision.Err))
+
+	assert.Empty(t, announcer.announced)
+	assert.Len(t, audit.Decisions(), 2)
+}
+
+func TestMaxTotalAmount(t *testing.T) {
+	rule := MaxTotalAmount(testCosignXpxId, 100, testResolveXpx)
+	recipient, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	aggregate := func(amounts ...sdk.Amount) *sdk.AggregateTransaction {
+		tx := &sdk.AggregateTransaction{}
+		for _, a := range amounts {
+			tx.InnerTransactions = append(tx.InnerTransactions, newFilterTransfer(t, recipient.Address, a))
+		}
+		return tx
+	}
+
+	assert.Nil(t, rule(nil, aggregate(60, 40)))
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(rule(nil, aggregate(60, 41))))
+	// sum of these amounts overflows to a negative one
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(MaxTotalAmount(testCosignXpxId, math.MaxInt64, testResolveXpx)(nil, aggregate(math.MaxInt64, 1))))
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(rule(nil, aggregate(60, -100))))
+
+	// the same mosaic sent by id and by alias is counted together
+	byId := newFilterTransfer(t, recipient.Address, 0)
+	byId.Mosaics = []*sdk.Mosaic{newCosignMosaic(t, testCosignXpxId, 60)}
+	tx := aggregate(40)
+	tx.InnerTransactions = append(tx.InnerTransactions, byId)
+	assert.Nil(t, rule(nil, tx))
+	tx.InnerTransactions = append(tx.InnerTransactions, newFilterTransfer(t, recipient.Address, 1))
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(rule(nil, tx)))
+
+	// other mosaics are not limited
+	other, err := sdk.NewMosaicId(0x1)
+	assert.Nil(t, err)
+	byId.Mosaics = []*sdk.Mosaic{newCosignMosaic(t, other, 1000)}
+	assert.Nil(t, MaxTotalAmount(testCosignXpxId, 100, testResolveXpx)(nil, &sdk.AggregateTransaction{InnerTransactions: []sdk.Transaction{byId}}))
+
+	// alias can't be checked without resolver
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(MaxTotalAmount(testCosignXpxId, 100, nil)(nil, aggregate(1))))
+	unknown := newFilterTransfer(t, recipient.Address, 0)
+	unknown.Mosaics = []*sdk.Mosaic{newCosignMosaic(t, sdk.StorageNamespaceId, 1)}
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(rule(nil, &sdk.AggregateTransaction{InnerTransactions: []sdk.Transaction{unknown}})))
+}
+
+func newCosignMosaic(t *testing.T, assetId sdk.AssetId, amount sdk.Amount) *sdk.Mosaic {
+	m, err := sdk.NewMosaic(assetId, amount)
+	assert.Nil(t, err)
+	return m
+}
+
+func TestAllowRecipients(t *testing.T) {
+	recipient, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+	rule := AllowRecipients(recipient.Address)
+
+	transfer := newFilterTransfer(t, recipient.Address, 1)
+	assert.Nil(t, rule(nil, &sdk.AggregateTransaction{InnerTransactions: []sdk.Transaction{transfer}}))
+
+	// transaction without recipient should be allowed by AllowEntityTypes instead
+	modify, err := sdk.NewModifyMultisigAccountTransaction(sdk.NewDeadline(0), 1, 1, nil, sdk.MijinTest)
+	assert.Nil(t, err)
+	aggregate := &sdk.AggregateTransaction{InnerTransactions: []sdk.Transaction{transfer, modify}}
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(rule(nil, aggregate)))
+}
+
+func TestAllowEntityTypes_EmptyAggregate(t *testing.T) {
+	err := AllowEntityTypes(sdk.Transfer)(nil, &sdk.AggregateTransaction{})
+	assert.Equal(t, ErrCosignRuleFailed, errors.Cause(err))
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+const defaultBlockStreamPageSize sdk.Amount = 100
+
+var ErrBlockStreamGap = errors.New("node returned incomplete range of blocks")
+
+// BlockSource is the part of sdk.BlockchainService used by BlockStreamer
+type BlockSource interface {
+	GetBlockchainHeight(ctx context.Context) (sdk.Height, error)
+	GetBlocksByHeightWithLimit(ctx context.Context, height sdk.Height, limit sdk.Amount) ([]*sdk.BlockInfo, error)
+	GetBlockTransactions(ctx context.Context, height sdk.Height) ([]sdk.Transaction, error)
+}
+
+// BlockReceiptsSource is the part of sdk.ReceiptService used by BlockStreamer
+type BlockReceiptsSource interface {
+	GetBlockReceipts(ctx context.Context, height sdk.Height) (*sdk.BlockStatement, error)
+}
+
+type blockHandlersAdder interface {
+	AddBlockHandlers(handlers ...subscribers.BlockHandler) error
+}
+
+// StreamedBlock is a block delivered by BlockStreamer. Transactions and Receipts are filled only when they are requested
+type StreamedBlock struct {
+	*sdk.BlockInfo
+	Transactions []sdk.Transaction
+	Receipts     *sdk.BlockStatement
+}
+
+type BlockStreamConfig struct {
+	// Number of blocks requested by one REST call. Zero means 100
+	PageSize sdk.Amount
+	// Requests transactions of every block with GetBlockTransactions
+	WithTransactions bool
+	// Requests receipts of every block when set, e.g. with sdk.Client.Receipt
+	Receipts BlockReceiptsSource
+	// Buffer size of returned blocks channel
+	BufferSize int
+}
+
+// BlockStreamer delivers historical blocks from REST and continues with blocks from websocket,
+// so every height is delivered exactly once and in order
+type BlockStreamer struct {
+	blockchain BlockSource
+	ws         blockHandlersAdder
+	config     BlockStreamConfig
+}
+
+// returns BlockStreamer. Passed websocket client should be listened by caller
+func NewBlockStreamer(blockchain BlockSource, ws CatapultClient, cfg BlockStreamConfig) *BlockStreamer {
+	if cfg.PageSize == 0 {
+		cfg.PageSize = defaultBlockStreamPageSize
+	}
+
+	return &BlockStreamer{
+		blockchain: blockchain,
+		ws:         ws,
+		config:     cfg,
+	}
+}
+
+// BlockStream returns blocks starting from passed height. Both channels are closed when ctx is done or on first error.
+// Blocks missed by websocket, e.g. during reconnection, are requested from REST
+func (s *BlockStreamer) BlockStream(ctx context.Context, fromHeight sdk.Height) (<-chan *StreamedBlock, <-chan error) {
+	blocksCh := make(chan *StreamedBlock, s.config.BufferSize)
+	errCh := make(chan error, 1)
+
+	if fromHeight == 0 {
+		errCh <- sdk.ErrNilOrZeroHeight
+		close(blocksCh)
+		close(errCh)
+		return blocksCh, errCh
+	}
+
+	live := newLiveBlocks()
+
+	// websocket is subscribed before catch up, so blocks produced meanwhile are buffered
+	err := s.ws.AddBlockHandlers(func(block *sdk.BlockInfo) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		live.push(block)
+		return false
+	})
+	if err != nil {
+		errCh <- errors.Wrap(err, "subscribing to blocks")
+		close(blocksCh)
+		close(errCh)
+		return blocksCh, errCh
+	}
+
+	go func() {
+		defer close(errCh)
+		defer close(blocksCh)
+
+		if err := s.stream(ctx, fromHeight, live, blocksCh); err != nil && ctx.Err() == nil {
+			errCh <- err
+		}
+	}()
+
+	return blocksCh, errCh
+}
+
+func (s *BlockStreamer) stream(ctx context.Context, next sdk.Height, live *liveBlocks, blocksCh chan<- *StreamedBlock) error {
+	height, err := s.blockchain.GetBlockchainHeight(ctx)
+	if err != nil {
+		return errors.Wrap(err, "getting blockchain height")
+	}
+
+	if next, err = s.catchUp(ctx, next, height, blocksCh); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-live.notifyCh:
+		}
+
+		for _, block := range live.pop() {
+			if block.Height < next {
+				// already delivered from REST
+				continue
+			}
+
+			if block.Height > next {
+				if next, err = s.catchUp(ctx, next, block.Height-1, blocksCh); err != nil {
+					return err
+				}
+			}
+
+			if err = s.send(ctx, block, blocksCh); err != nil {
+				return err
+			}
+			next = block.Height + 1
+		}
+	}
+}
+
+// delivers blocks [from, to] from REST and returns next expected height
+func (s *BlockStreamer) catchUp(ctx context.Context, from, to sdk.Height, blocksCh chan<- *StreamedBlock) (sdk.Height, error) {
+	for from <= to {
+		page, err := s.blockchain.GetBlocksByHeightWithLimit(ctx, from, s.config.PageSize)
+		if err != nil {
+			return from, errors.Wrapf(err, "getting blocks from height %d", from)
+		}
+
+		sort.Slice(page, func(i, j int) bool {
+			return page[i].Height < page[j].Height
+		})
+
+		start := from
+		for _, block := range page {
+			if block.Height < from || block.Height > to {
+				continue
+			}
+
+			if block.Height != from {
+				return from, errors.Wrapf(ErrBlockStreamGap, "expected block %d, got %d", from, block.Height)
+			}
+
+			if err = s.send(ctx, block, blocksCh); err != nil {
+				return from, err
+			}
+			from++
+		}
+
+		if from == start {
+			return from, errors.Wrapf(ErrBlockStreamGap, "block %d is not returned", from)
+		}
+	}
+
+	return from, nil
+}
+
+func (s *BlockStreamer) send(ctx context.Context, block *sdk.BlockInfo, blocksCh chan<- *StreamedBlock) error {
+	streamed := &StreamedBlock{BlockInfo: block}
+
+	if s.config.WithTransactions && block.NumTransactions > 0 {
+		txs, err := s.blockchain.GetBlockTransactions(ctx, block.Height)
+		if err != nil {
+			return errors.Wrapf(err, "getting transactions of block %d", block.Height)
+		}
+
+		streamed.Transactions = txs
+	}
+
+	if s.config.Receipts != nil {
+		receipts, err := s.config.Receipts.GetBlockReceipts(ctx, block.Height)
+		if err != nil {
+			return errors.Wrapf(err, "getting receipts of block %d", block.Height)
+		}
+
+		streamed.Receipts = receipts
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case blocksCh <- streamed:
+		return nil
+	}
+}
+
+// liveBlocks buffers blocks from websocket, so slow consumer doesn't block the router
+type liveBlocks struct {
+	sync.Mutex
+	blocks   []*sdk.BlockInfo
+	notifyCh chan struct{}
+}
+
+func newLiveBlocks() *liveBlocks {
+	return &liveBlocks{notifyCh: make(chan struct{}, 1)}
+}
+
+func (l *liveBlocks) push(block *sdk.BlockInfo) {
+	l.Lock()
+	l.blocks = append(l.blocks, block)
+	l.Unlock()
+
+	select {
+	case l.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (l *liveBlocks) pop() []*sdk.BlockInfo {
+	l.Lock()
+	defer l.Unlock()
+
+	blocks := l.blocks
+	l.blocks = nil
+
+	return blocks
+}
@@ -0,0 +1,133 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+type fakeBlockSource struct {
+	sync.Mutex
+	height   sdk.Height
+	requests []sdk.Height
+}
+
+func (s *fakeBlockSource) GetBlockchainHeight(context.Context) (sdk.Height, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.height, nil
+}
+
+// returns blocks in descending order like REST does
+func (s *fakeBlockSource) GetBlocksByHeightWithLimit(_ context.Context, height sdk.Height, limit sdk.Amount) ([]*sdk.BlockInfo, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.requests = append(s.requests, height)
+
+	blocks := make([]*sdk.BlockInfo, 0)
+	for h := height + sdk.Height(limit) - 1; h >= height; h-- {
+		if h <= s.height {
+			blocks = append(blocks, &sdk.BlockInfo{Height: h, NumTransactions: 1})
+		}
+	}
+
+	return blocks, nil
+}
+
+func (s *fakeBlockSource) GetBlockTransactions(_ context.Context, height sdk.Height) ([]sdk.Transaction, error) {
+	return []sdk.Transaction{&sdk.TransferTransaction{AbstractTransaction: sdk.AbstractTransaction{
+		TransactionInfo: sdk.TransactionInfo{Height: height},
+	}}}, nil
+}
+
+func (s *fakeBlockSource) GetBlockReceipts(_ context.Context, height sdk.Height) (*sdk.BlockStatement, error) {
+	return &sdk.BlockStatement{TransactionStatements: []*sdk.TransactionStatement{{Height: height}}}, nil
+}
+
+type fakeBlockHandlersAdder struct {
+	handlerCh chan subscribers.BlockHandler
+}
+
+func (a *fakeBlockHandlersAdder) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
+	a.handlerCh <- handlers[0]
+	return nil
+}
+
+func receiveHeights(t *testing.T, blocksCh <-chan *StreamedBlock, count int) []sdk.Height {
+	heights := make([]sdk.Height, 0, count)
+	for len(heights) < count {
+		select {
+		case b := <-blocksCh:
+			heights = append(heights, b.Height)
+		case <-time.After(time.Second):
+			t.Fatalf("received only %d blocks", len(heights))
+		}
+	}
+
+	return heights
+}
+
+func TestBlockStreamer_BlockStream(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	source := &fakeBlockSource{height: 7}
+	ws := &fakeBlockHandlersAdder{handlerCh: make(chan subscribers.BlockHandler, 1)}
+
+	s := &BlockStreamer{
+		blockchain: source,
+		ws:         ws,
+		config:     BlockStreamConfig{PageSize: 3, WithTransactions: true, Receipts: source},
+	}
+
+	blocksCh, errCh := s.BlockStream(ctx, 2)
+	handler := <-ws.handlerCh
+
+	assert.Equal(t, []sdk.Height{2, 3, 4, 5, 6, 7}, receiveHeights(t, blocksCh, 6))
+
+	// duplicate of block delivered from REST is skipped
+	assert.False(t, handler(&sdk.BlockInfo{Height: 7}))
+	assert.False(t, handler(&sdk.BlockInfo{Height: 8}))
+
+	// blocks 9 and 10 are missed by websocket and requested from REST
+	source.Lock()
+	source.height = 11
+	source.Unlock()
+	assert.False(t, handler(&sdk.BlockInfo{Height: 11}))
+
+	blocks := make([]*StreamedBlock, 0)
+	for len(blocks) < 4 {
+		blocks = append(blocks, <-blocksCh)
+	}
+
+	assert.Equal(t, sdk.Height(8), blocks[0].Height)
+	assert.Equal(t, sdk.Height(9), blocks[1].Height)
+	assert.Equal(t, sdk.Height(10), blocks[2].Height)
+	assert.Equal(t, sdk.Height(11), blocks[3].Height)
+	assert.Len(t, blocks[1].Transactions, 1)
+	assert.Nil(t, blocks[0].Transactions)
+	assert.Equal(t, sdk.Height(9), blocks[1].Receipts.TransactionStatements[0].Height)
+
+	cancel()
+
+	_, ok := <-errCh
+	assert.False(t, ok)
+	assert.True(t, handler(&sdk.BlockInfo{Height: 12}))
+}
+
+func TestBlockStreamer_BlockStream_ZeroHeight(t *testing.T) {
+	s := &BlockStreamer{blockchain: &fakeBlockSource{}, ws: &fakeBlockHandlersAdder{}}
+
+	_, errCh := s.BlockStream(context.Background(), 0)
+	assert.Equal(t, sdk.ErrNilOrZeroHeight, <-errCh)
+}
@@ -10,6 +10,7 @@ import (
 	"io"
 	"net"
 	"net/url"
+	"sync"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -62,6 +63,10 @@ type (
 		messageRouter    Router
 		topicHandlers    TopicHandlersStorage
 		messagePublisher MessagePublisher
+		dispatcherConfig DispatcherConfig
+
+		customTopicsLock sync.RWMutex
+		customTopics     map[Path]*customTopic
 
 		// connectionStatusCh chan bool
 		listenCh     chan bool            // channel for manage current listen status for connection
@@ -90,10 +95,42 @@ type (
 		AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error
 		AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error
 		AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error
+
+		AddConfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.ConfirmedAddedHandler) error
+		AddUnconfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.UnconfirmedAddedHandler) error
+		OnTransfer(address *sdk.Address, handler TransferHandler) error
+		OnLockFunds(address *sdk.Address, handler LockFundsHandler) error
+		OnSecretLock(address *sdk.Address, handler SecretLockHandler) error
+		OnSecretProof(address *sdk.Address, handler SecretProofHandler) error
+
+		// Bulk methods cover topics used to watch activity of many accounts: confirmed and unconfirmed transactions
+		// and their statuses. Partial, cosignature and drive topics are scoped to multisig accounts and drives,
+		// which are watched one by one, so they keep only per-address methods
+		AddConfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error
+		AddUnconfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error
+		AddStatusHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.StatusHandler) error
+		RemoveConfirmedAddedAddresses(addresses ...*sdk.Address) error
+		RemoveUnconfirmedAddedAddresses(addresses ...*sdk.Address) error
+		RemoveStatusAddresses(addresses ...*sdk.Address) error
+
+		// returns snapshot of handlers dispatcher queues
+		Metrics() DispatcherMetrics
+
+		RegisterTopic(topic *CustomTopic) error
+		AddTopicHandlers(name string, address *sdk.Address, handlers ...subscribers.CustomHandler) error
 	}
 )
 
 func NewClient(ctx context.Context, cfg *sdk.Config) (CatapultClient, error) {
+	return NewClientWithDispatcherConfig(ctx, cfg, DefaultDispatcherConfig())
+}
+
+// returns CatapultClient which runs handlers in pool of workers configured by DispatcherConfig
+func NewClientWithDispatcherConfig(ctx context.Context, cfg *sdk.Config, dispatcherCfg DispatcherConfig) (CatapultClient, error) {
+	if err := dispatcherCfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	ctx, cancelFunc := context.WithCancel(ctx)
 
 	socketClient := &CatapultWebsocketClientImpl{
@@ -112,7 +149,9 @@ func NewClient(ctx context.Context, cfg *sdk.Config) (CatapultClient, error) {
 		unconfirmedAddedSubscribers:   subscribers.NewUnconfirmedAdded(),
 		unconfirmedRemovedSubscribers: subscribers.NewUnconfirmedRemoved(),
 
-		topicHandlers: &topicHandlers{h: make(topicHandlersMap)},
+		topicHandlers:    &topicHandlers{h: make(topicHandlersMap)},
+		dispatcherConfig: dispatcherCfg,
+		customTopics:     make(map[Path]*customTopic),
 
 		listenCh:     make(chan bool),
 		reconnectCh:  make(chan *websocket.Conn),
@@ -137,6 +176,9 @@ func (c *CatapultWebsocketClientImpl) Listen() {
 	select {
 	case <-c.ctx.Done():
 		c.closeConnection(c.conn)
+		if c.messageRouter != nil {
+			c.messageRouter.Close()
+		}
 	}
 }
 
@@ -149,6 +191,14 @@ func (c *CatapultWebsocketClientImpl) Config() *sdk.Config {
 	return c.config
 }
 
+func (c *CatapultWebsocketClientImpl) Metrics() DispatcherMetrics {
+	if c.messageRouter == nil {
+		return DispatcherMetrics{}
+	}
+
+	return c.messageRouter.Metrics()
+}
+
 func (c *CatapultWebsocketClientImpl) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
 	if len(handlers) == 0 {
 		return nil
@@ -380,6 +430,223 @@ func (c *CatapultWebsocketClientImpl) AddDriveStateHandlers(address *sdk.Address
 	return nil
 }
 
+// registers handlers for confirmedAdded topic, which are called only for transactions passed the filter
+func (c *CatapultWebsocketClientImpl) AddConfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.ConfirmedAddedHandler) error {
+	filtered := make([]subscribers.ConfirmedAddedHandler, len(handlers))
+	for i, h := range handlers {
+		filtered[i] = filterConfirmedAddedHandler(filter, h)
+	}
+
+	return c.AddConfirmedAddedHandlers(address, filtered...)
+}
+
+// registers handlers for unconfirmedAdded topic, which are called only for transactions passed the filter
+func (c *CatapultWebsocketClientImpl) AddUnconfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	filtered := make([]subscribers.UnconfirmedAddedHandler, len(handlers))
+	for i, h := range handlers {
+		filtered[i] = filterUnconfirmedAddedHandler(filter, h)
+	}
+
+	return c.AddUnconfirmedAddedHandlers(address, filtered...)
+}
+
+// registers handler for confirmed TransferTransaction's of address, including inner transfers of aggregates
+func (c *CatapultWebsocketClientImpl) OnTransfer(address *sdk.Address, handler TransferHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	return c.AddConfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+		for _, t := range flattenTransaction(tx) {
+			if typed, ok := t.(*sdk.TransferTransaction); ok && handler(typed) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// registers handler for confirmed LockFundsTransaction's of address
+func (c *CatapultWebsocketClientImpl) OnLockFunds(address *sdk.Address, handler LockFundsHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	return c.AddConfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+		for _, t := range flattenTransaction(tx) {
+			if typed, ok := t.(*sdk.LockFundsTransaction); ok && handler(typed) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// registers handler for confirmed SecretLockTransaction's of address, including inner ones of aggregates
+func (c *CatapultWebsocketClientImpl) OnSecretLock(address *sdk.Address, handler SecretLockHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	return c.AddConfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+		for _, t := range flattenTransaction(tx) {
+			if typed, ok := t.(*sdk.SecretLockTransaction); ok && handler(typed) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// registers handler for confirmed SecretProofTransaction's of address, including inner ones of aggregates
+func (c *CatapultWebsocketClientImpl) OnSecretProof(address *sdk.Address, handler SecretProofHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	return c.AddConfirmedAddedHandlers(address, func(tx sdk.Transaction) bool {
+		for _, t := range flattenTransaction(tx) {
+			if typed, ok := t.(*sdk.SecretProofTransaction); ok && handler(typed) {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// registers the same handlers for many addresses of confirmedAdded topic. Subscribe messages for new addresses are sent in one batch
+func (c *CatapultWebsocketClientImpl) AddConfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	if len(handlers) == 0 || len(addresses) == 0 {
+		return nil
+	}
+
+	if !c.topicHandlers.HasHandler(pathConfirmedAdded) {
+		c.topicHandlers.SetTopicHandler(pathConfirmedAdded, &TopicHandler{
+			Handler: hdlrs.NewConfirmedAddedHandler(sdk.NewConfirmedAddedMapper(sdk.MapTransaction, c.config.GenerationHash), c.confirmedAddedSubscribers),
+			Topic:   topicFormatFn(formatPlainTopic),
+		})
+	}
+
+	return c.addAddressesHandlers(pathConfirmedAdded, addresses, c.confirmedAddedSubscribers.HasHandlers, func(addresses []*sdk.Address) error {
+		return c.confirmedAddedSubscribers.AddHandlersForAddresses(addresses, handlers...)
+	})
+}
+
+// registers the same handlers for many addresses of unconfirmedAdded topic. Subscribe messages for new addresses are sent in one batch
+func (c *CatapultWebsocketClientImpl) AddUnconfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	if len(handlers) == 0 || len(addresses) == 0 {
+		return nil
+	}
+
+	if !c.topicHandlers.HasHandler(pathUnconfirmedAdded) {
+		c.topicHandlers.SetTopicHandler(pathUnconfirmedAdded, &TopicHandler{
+			Handler: hdlrs.NewUnconfirmedAddedHandler(sdk.NewUnconfirmedAddedMapper(sdk.MapTransaction, c.config.GenerationHash), c.unconfirmedAddedSubscribers),
+			Topic:   topicFormatFn(formatPlainTopic),
+		})
+	}
+
+	return c.addAddressesHandlers(pathUnconfirmedAdded, addresses, c.unconfirmedAddedSubscribers.HasHandlers, func(addresses []*sdk.Address) error {
+		return c.unconfirmedAddedSubscribers.AddHandlersForAddresses(addresses, handlers...)
+	})
+}
+
+// registers the same handlers for many addresses of status topic. Subscribe messages for new addresses are sent in one batch
+func (c *CatapultWebsocketClientImpl) AddStatusHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.StatusHandler) error {
+	if len(handlers) == 0 || len(addresses) == 0 {
+		return nil
+	}
+
+	if !c.topicHandlers.HasHandler(pathStatus) {
+		c.topicHandlers.SetTopicHandler(pathStatus, &TopicHandler{
+			Handler: hdlrs.NewStatusHandler(sdk.StatusMapperFn(sdk.MapStatus), c.statusSubscribers),
+			Topic:   topicFormatFn(formatPlainTopic),
+		})
+	}
+
+	return c.addAddressesHandlers(pathStatus, addresses, c.statusSubscribers.HasHandlers, func(addresses []*sdk.Address) error {
+		return c.statusSubscribers.AddHandlersForAddresses(addresses, handlers...)
+	})
+}
+
+// removes all handlers of passed addresses from confirmedAdded topic and unsubscribes from them in one batch
+func (c *CatapultWebsocketClientImpl) RemoveConfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	return c.removeAddresses(pathConfirmedAdded, addresses, c.confirmedAddedSubscribers.RemoveAddress)
+}
+
+// removes all handlers of passed addresses from unconfirmedAdded topic and unsubscribes from them in one batch
+func (c *CatapultWebsocketClientImpl) RemoveUnconfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	return c.removeAddresses(pathUnconfirmedAdded, addresses, c.unconfirmedAddedSubscribers.RemoveAddress)
+}
+
+// removes all handlers of passed addresses from status topic and unsubscribes from them in one batch
+func (c *CatapultWebsocketClientImpl) RemoveStatusAddresses(addresses ...*sdk.Address) error {
+	return c.removeAddresses(pathStatus, addresses, c.statusSubscribers.RemoveAddress)
+}
+
+func (c *CatapultWebsocketClientImpl) addAddressesHandlers(path Path, addresses []*sdk.Address, hasHandlers func(*sdk.Address) bool, addHandlers func([]*sdk.Address) error) error {
+	paths := make([]Path, 0, len(addresses))
+	for _, address := range addresses {
+		if !hasHandlers(address) {
+			paths = append(paths, addressPath(path, address.Address))
+		}
+	}
+
+	if len(paths) > 0 {
+		if err := c.messagePublisher.PublishSubscribeMessages(c.UID, paths...); err != nil {
+			return errors.Wrap(err, "publishing subscribe messages into websocket")
+		}
+	}
+
+	if err := addHandlers(addresses); err != nil {
+		return errors.Wrap(err, "adding handlers functions into handlers storage")
+	}
+
+	return nil
+}
+
+func (c *CatapultWebsocketClientImpl) removeAddresses(path Path, addresses []*sdk.Address, removeAddress func(*sdk.Address) bool) error {
+	paths := make([]Path, 0, len(addresses))
+	for _, address := range addresses {
+		if removeAddress(address) {
+			paths = append(paths, addressPath(path, address.Address))
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if err := c.messagePublisher.PublishUnsubscribeMessages(c.UID, paths...); err != nil {
+		return errors.Wrap(err, "publishing unsubscribe messages into websocket")
+	}
+
+	return nil
+}
+
+func filterConfirmedAddedHandler(filter TransactionFilter, handler subscribers.ConfirmedAddedHandler) subscribers.ConfirmedAddedHandler {
+	return func(tx sdk.Transaction) bool {
+		if !filter.Match(tx) {
+			return false
+		}
+
+		return handler(tx)
+	}
+}
+
+func filterUnconfirmedAddedHandler(filter TransactionFilter, handler subscribers.UnconfirmedAddedHandler) subscribers.UnconfirmedAddedHandler {
+	return func(tx sdk.Transaction) bool {
+		if !filter.Match(tx) {
+			return false
+		}
+
+		return handler(tx)
+	}
+}
+
 func (c *CatapultWebsocketClientImpl) handleSignal() {
 	for {
 		select {
@@ -462,7 +729,9 @@ func (c *CatapultWebsocketClientImpl) startListener() {
 			}
 		}
 
-		c.messageRouter.RouteMessage(resp)
+		if err := c.messageRouter.RouteMessage(resp); err != nil {
+			fmt.Println(err, "routing websocket message")
+		}
 	}
 }
 
@@ -475,8 +744,18 @@ func (c *CatapultWebsocketClientImpl) initNewConnection() error {
 	c.UID = uid
 	c.conn = conn
 
+	// router is kept across connections, so messages already read from previous connection are still handled
+	if c.messageRouter != nil {
+		c.messagePublisher.SetConn(conn)
+		c.messageRouter.SetUid(uid)
+		return nil
+	}
+
 	messagePublisher := newMessagePublisher(c.conn)
-	messageRouter := NewRouter(c.UID, messagePublisher, c.topicHandlers)
+	messageRouter, err := NewRouterWithConfig(c.UID, messagePublisher, c.topicHandlers, c.dispatcherConfig)
+	if err != nil {
+		return err
+	}
 
 	c.messageRouter = messageRouter
 	c.messagePublisher = messagePublisher
@@ -485,56 +764,39 @@ func (c *CatapultWebsocketClientImpl) initNewConnection() error {
 }
 
 func (c *CatapultWebsocketClientImpl) updateHandlers() error {
+	paths := make([]Path, 0)
 
 	if c.topicHandlers.HasHandler(pathBlock) {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s", pathBlock))); err != nil {
-			return err
-		}
-	}
-
-	for _, value := range c.confirmedAddedSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathConfirmedAdded, value))); err != nil {
-			return err
-		}
-	}
-
-	for _, value := range c.cosignatureSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathCosignature, value))); err != nil {
-			return err
-		}
+		paths = append(paths, pathBlock)
 	}
 
-	for _, value := range c.partialAddedSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathPartialAdded, value))); err != nil {
-			return err
-		}
-	}
+	paths = appendAddressPaths(paths, pathConfirmedAdded, c.confirmedAddedSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathCosignature, c.cosignatureSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, driveState, c.driveStateSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathPartialAdded, c.partialAddedSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathPartialRemoved, c.partialRemovedSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathStatus, c.statusSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathUnconfirmedAdded, c.unconfirmedAddedSubscribers.GetAddresses())
+	paths = appendAddressPaths(paths, pathUnconfirmedRemoved, c.unconfirmedRemovedSubscribers.GetAddresses())
+	paths = append(paths, c.customTopicPaths()...)
 
-	for _, value := range c.partialRemovedSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathPartialRemoved, value))); err != nil {
-			return err
-		}
+	if len(paths) == 0 {
+		return nil
 	}
 
-	for _, value := range c.statusSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathStatus, value))); err != nil {
-			return err
-		}
-	}
+	return c.messagePublisher.PublishSubscribeMessages(c.UID, paths...)
+}
 
-	for _, value := range c.unconfirmedAddedSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathUnconfirmedAdded, value))); err != nil {
-			return err
-		}
-	}
+func addressPath(path Path, address string) Path {
+	return Path(fmt.Sprintf("%s/%s", path, address))
+}
 
-	for _, value := range c.unconfirmedRemovedSubscribers.GetAddresses() {
-		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, Path(fmt.Sprintf("%s/%s", pathUnconfirmedRemoved, value))); err != nil {
-			return err
-		}
+func appendAddressPaths(paths []Path, path Path, addresses []string) []Path {
+	for _, address := range addresses {
+		paths = append(paths, addressPath(path, address))
 	}
 
-	return nil
+	return paths
 }
 
 func connect(cfg *sdk.Config) (*websocket.Conn, string, error) {
@@ -972,3 +972,96 @@ func TestCatapultWebsocketClientImpl_AddCosignatureHandlers(t *testing.T) {
 		})
 	}
 }
+
+func TestCatapultWebsocketClientImpl_AddConfirmedAddedHandlersForAddresses(t *testing.T) {
+	uid := "123456"
+
+	subscribed := &sdk.Address{Address: "subscribed-address"}
+	fresh1 := &sdk.Address{Address: "fresh-address-1"}
+	fresh2 := &sdk.Address{Address: "fresh-address-2"}
+
+	handler := func(sdk.Transaction) bool {
+		return false
+	}
+
+	mockMessagePublisher := new(MockMessagePublisher)
+	mockMessagePublisher.On("PublishSubscribeMessages", uid,
+		Path("confirmedAdded/fresh-address-1"), Path("confirmedAdded/fresh-address-2")).Return(nil).Once()
+
+	mockTopicHandler := new(MockTopicHandlersStorage)
+	mockTopicHandler.On("HasHandler", mock.Anything).Return(true)
+
+	mockSubscribers := new(mocks.ConfirmedAdded)
+	mockSubscribers.On("HasHandlers", subscribed).Return(true).
+		On("HasHandlers", fresh1).Return(false).
+		On("HasHandlers", fresh2).Return(false).
+		On("AddHandlersForAddresses", []*sdk.Address{subscribed, fresh1, fresh2}, mock.Anything).Return(nil)
+
+	c := &CatapultWebsocketClientImpl{
+		UID:                       uid,
+		confirmedAddedSubscribers: mockSubscribers,
+		topicHandlers:             mockTopicHandler,
+		messagePublisher:          mockMessagePublisher,
+		config:                    &sdk.Config{},
+	}
+
+	err := c.AddConfirmedAddedHandlersForAddresses([]*sdk.Address{subscribed, fresh1, fresh2}, handler)
+	assert.Nil(t, err)
+
+	mockMessagePublisher.AssertExpectations(t)
+	mockSubscribers.AssertNumberOfCalls(t, "AddHandlersForAddresses", 1)
+	mockSubscribers.AssertNotCalled(t, "AddHandlers", mock.Anything, mock.Anything)
+}
+
+func TestCatapultWebsocketClientImpl_RemoveConfirmedAddedAddresses(t *testing.T) {
+	uid := "123456"
+
+	subscribed := &sdk.Address{Address: "subscribed-address"}
+	unknown := &sdk.Address{Address: "unknown-address"}
+
+	mockMessagePublisher := new(MockMessagePublisher)
+	mockMessagePublisher.On("PublishUnsubscribeMessages", uid, Path("confirmedAdded/subscribed-address")).Return(nil).Once()
+
+	mockSubscribers := new(mocks.ConfirmedAdded)
+	mockSubscribers.On("RemoveAddress", subscribed).Return(true).Once().
+		On("RemoveAddress", unknown).Return(false).Once()
+
+	c := &CatapultWebsocketClientImpl{
+		UID:                       uid,
+		confirmedAddedSubscribers: mockSubscribers,
+		messagePublisher:          mockMessagePublisher,
+	}
+
+	err := c.RemoveConfirmedAddedAddresses(subscribed, unknown)
+	assert.Nil(t, err)
+
+	mockMessagePublisher.AssertExpectations(t)
+	mockSubscribers.AssertExpectations(t)
+}
+
+func TestCatapultWebsocketClientImpl_RemoveConfirmedAddedAddresses_SeveralHandlers(t *testing.T) {
+	uid := "123456"
+	address := &sdk.Address{Address: "subscribed-address"}
+
+	mockMessagePublisher := new(MockMessagePublisher)
+	mockMessagePublisher.On("PublishUnsubscribeMessages", uid, Path("confirmedAdded/subscribed-address")).Return(nil).Once()
+
+	confirmedAdded := subscribers.NewConfirmedAdded()
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, confirmedAdded.AddHandlers(address, func(sdk.Transaction) bool {
+			return false
+		}))
+	}
+
+	c := &CatapultWebsocketClientImpl{
+		UID:                       uid,
+		confirmedAddedSubscribers: confirmedAdded,
+		messagePublisher:          mockMessagePublisher,
+	}
+
+	assert.Nil(t, c.RemoveConfirmedAddedAddresses(address))
+	assert.False(t, confirmedAdded.HasHandlers(address))
+	assert.Empty(t, confirmedAdded.GetAddresses())
+
+	mockMessagePublisher.AssertExpectations(t)
+}
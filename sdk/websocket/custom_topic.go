@@ -0,0 +1,180 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	hdlrs "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/handlers"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+// TopicScope defines how subscriptions of custom topic are bound to addresses
+type TopicScope uint8
+
+// TopicScope enums
+const (
+	// topic is subscribed by channel name, like block
+	TopicScopeGlobal TopicScope = iota
+	// topic is subscribed per address as channelName/address, like confirmedAdded
+	TopicScopeAddress
+)
+
+var (
+	ErrNilTopic               = errors.New("topic should not be nil")
+	ErrEmptyTopicName         = errors.New("topic name should not be empty")
+	ErrNilTopicDecoder        = errors.New("topic decoder should not be nil")
+	ErrTopicAlreadyRegistered = errors.New("topic is already registered")
+	ErrTopicNotRegistered     = errors.New("topic is not registered")
+	ErrTopicAddressRequired   = errors.New("address is required for topic bound to addresses")
+	ErrTopicAddressNotAllowed = errors.New("address is not allowed for global topic")
+)
+
+// topics which are served by the SDK itself and can't be registered as custom ones
+var builtInTopics = map[Path]bool{
+	pathBlock:              true,
+	pathConfirmedAdded:     true,
+	pathUnconfirmedAdded:   true,
+	pathUnconfirmedRemoved: true,
+	pathStatus:             true,
+	pathPartialAdded:       true,
+	pathPartialRemoved:     true,
+	pathCosignature:        true,
+	driveState:             true,
+}
+
+// CustomTopic describes websocket channel which is not known to the SDK, e.g. published by an extra node plugin
+type CustomTopic struct {
+	// Channel name as it is sent in message meta
+	Name  string
+	Scope TopicScope
+	// Converts raw message into value passed to handlers
+	Decoder hdlrs.CustomDecoder
+}
+
+type customTopic struct {
+	*CustomTopic
+	subscribers subscribers.Custom
+}
+
+func (t *CustomTopic) validate() error {
+	if t.Name == "" {
+		return ErrEmptyTopicName
+	}
+
+	if t.Decoder == nil {
+		return ErrNilTopicDecoder
+	}
+
+	if builtInTopics[Path(t.Name)] {
+		return ErrTopicAlreadyRegistered
+	}
+
+	return nil
+}
+
+func (t *CustomTopic) path(address *sdk.Address) (Path, error) {
+	switch t.Scope {
+	case TopicScopeAddress:
+		if address == nil {
+			return "", ErrTopicAddressRequired
+		}
+		return addressPath(Path(t.Name), address.Address), nil
+	default:
+		if address != nil {
+			return "", ErrTopicAddressNotAllowed
+		}
+		return Path(t.Name), nil
+	}
+}
+
+func (t *CustomTopic) format(info *sdk.WsMessageInfo) Path {
+	if t.Scope == TopicScopeAddress && info.Address != nil {
+		return addressPath(Path(t.Name), info.Address.Address)
+	}
+
+	return Path(t.Name)
+}
+
+// RegisterTopic makes client able to route messages of custom topic. Handlers are added with AddTopicHandlers
+func (c *CatapultWebsocketClientImpl) RegisterTopic(topic *CustomTopic) error {
+	if topic == nil {
+		return ErrNilTopic
+	}
+
+	if err := topic.validate(); err != nil {
+		return err
+	}
+
+	c.customTopicsLock.Lock()
+	defer c.customTopicsLock.Unlock()
+
+	if _, ok := c.customTopics[Path(topic.Name)]; ok {
+		return ErrTopicAlreadyRegistered
+	}
+
+	t := &customTopic{CustomTopic: topic, subscribers: subscribers.NewCustom()}
+	c.customTopics[Path(topic.Name)] = t
+
+	c.topicHandlers.SetTopicHandler(Path(topic.Name), &TopicHandler{
+		Handler: hdlrs.NewCustomHandler(topic.Decoder, t.subscribers),
+		Topic:   topicFormatFn(topic.format),
+	})
+
+	return nil
+}
+
+// AddTopicHandlers subscribes handlers to registered custom topic.
+// Address should be nil for TopicScopeGlobal topics and set for TopicScopeAddress ones
+func (c *CatapultWebsocketClientImpl) AddTopicHandlers(name string, address *sdk.Address, handlers ...subscribers.CustomHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	c.customTopicsLock.RLock()
+	topic, ok := c.customTopics[Path(name)]
+	c.customTopicsLock.RUnlock()
+	if !ok {
+		return ErrTopicNotRegistered
+	}
+
+	path, err := topic.path(address)
+	if err != nil {
+		return err
+	}
+
+	if !topic.subscribers.HasHandlers(address) {
+		if err := c.messagePublisher.PublishSubscribeMessage(c.UID, path); err != nil {
+			return errors.Wrap(err, "publishing subscribe message into websocket")
+		}
+	}
+
+	if err := topic.subscribers.AddHandlers(address, handlers...); err != nil {
+		return errors.Wrap(err, "adding handlers functions into handlers storage")
+	}
+
+	return nil
+}
+
+// returns subscribe paths of custom topics with handlers
+func (c *CatapultWebsocketClientImpl) customTopicPaths() []Path {
+	c.customTopicsLock.RLock()
+	defer c.customTopicsLock.RUnlock()
+
+	paths := make([]Path, 0)
+	for name, topic := range c.customTopics {
+		if topic.Scope == TopicScopeAddress {
+			paths = appendAddressPaths(paths, name, topic.subscribers.GetAddresses())
+			continue
+		}
+
+		if topic.subscribers.HasHandlers(nil) {
+			paths = append(paths, name)
+		}
+	}
+
+	return paths
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+const customTopicMessage = `{"meta": {"channelName": "modelUpdated", "address": "90534434E016CAA132AB5EAC70C0AF7DF043B990C789A93EB1"}, "model": "v2"}`
+
+type customTopicModel struct {
+	Model string `json:"model"`
+}
+
+func decodeCustomTopicModel(data []byte) (interface{}, error) {
+	m := &customTopicModel{}
+	return m, json.Unmarshal(data, m)
+}
+
+func newCustomTopicClient(publisher MessagePublisher) *CatapultWebsocketClientImpl {
+	return &CatapultWebsocketClientImpl{
+		UID:              "uid",
+		topicHandlers:    &topicHandlers{h: make(topicHandlersMap)},
+		messagePublisher: publisher,
+		customTopics:     make(map[Path]*customTopic),
+		config:           &sdk.Config{},
+	}
+}
+
+func TestCatapultWebsocketClientImpl_RegisterTopic(t *testing.T) {
+	c := newCustomTopicClient(new(MockMessagePublisher))
+
+	assert.Equal(t, ErrNilTopic, c.RegisterTopic(nil))
+	assert.Equal(t, ErrEmptyTopicName, c.RegisterTopic(&CustomTopic{Decoder: decodeCustomTopicModel}))
+	assert.Equal(t, ErrNilTopicDecoder, c.RegisterTopic(&CustomTopic{Name: "modelUpdated"}))
+	assert.Equal(t, ErrTopicAlreadyRegistered, c.RegisterTopic(&CustomTopic{Name: "block", Decoder: decodeCustomTopicModel}))
+
+	topic := &CustomTopic{Name: "modelUpdated", Scope: TopicScopeAddress, Decoder: decodeCustomTopicModel}
+	assert.Nil(t, c.RegisterTopic(topic))
+	assert.Equal(t, ErrTopicAlreadyRegistered, c.RegisterTopic(topic))
+	assert.True(t, c.topicHandlers.HasHandler("modelUpdated"))
+}
+
+func TestCatapultWebsocketClientImpl_AddTopicHandlers(t *testing.T) {
+	address, err := sdk.NewAddressFromBase32(routerTestAddress)
+	assert.Nil(t, err)
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishSubscribeMessage", "uid", Path("modelUpdated/"+address.Address)).Return(nil).Once().
+		On("PublishSubscribeMessage", "uid", Path("peersChanged")).Return(nil).Once().
+		On("PublishUnsubscribeMessage", "uid", Path("modelUpdated/"+address.Address)).Return(nil).Once()
+
+	c := newCustomTopicClient(publisher)
+	assert.Nil(t, c.RegisterTopic(&CustomTopic{Name: "modelUpdated", Scope: TopicScopeAddress, Decoder: decodeCustomTopicModel}))
+	assert.Nil(t, c.RegisterTopic(&CustomTopic{Name: "peersChanged", Decoder: decodeCustomTopicModel}))
+
+	assert.Equal(t, ErrTopicNotRegistered, c.AddTopicHandlers("unknown", address, func(interface{}) bool { return false }))
+	assert.Equal(t, ErrTopicAddressRequired, c.AddTopicHandlers("modelUpdated", nil, func(interface{}) bool { return false }))
+	assert.Equal(t, ErrTopicAddressNotAllowed, c.AddTopicHandlers("peersChanged", address, func(interface{}) bool { return false }))
+
+	var got []*customTopicModel
+	assert.Nil(t, c.AddTopicHandlers("modelUpdated", address, func(m interface{}) bool {
+		got = append(got, m.(*customTopicModel))
+		return true
+	}))
+	assert.Nil(t, c.AddTopicHandlers("peersChanged", nil, func(interface{}) bool { return false }))
+
+	assert.ElementsMatch(t, []Path{Path("modelUpdated/" + address.Address), "peersChanged"}, c.customTopicPaths())
+
+	r := &messageRouter{
+		uid:               "uid",
+		topicHandlers:     c.topicHandlers,
+		messageInfoMapper: messageInfoMapperFn(MapMessageInfo),
+		messagePublisher:  publisher,
+	}
+	r.route([]byte(customTopicMessage))
+
+	assert.Equal(t, []*customTopicModel{{Model: "v2"}}, got)
+	assert.Equal(t, []Path{"peersChanged"}, c.customTopicPaths())
+	publisher.AssertExpectations(t)
+}
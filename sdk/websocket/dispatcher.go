@@ -0,0 +1,130 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"errors"
+	"hash/fnv"
+)
+
+// OverflowPolicy defines what happens with a new message when the queue is full
+type OverflowPolicy uint8
+
+// OverflowPolicy enums
+const (
+	// wait until the queue has free space
+	OverflowBlock OverflowPolicy = iota
+	// drop the oldest queued message and enqueue the new one
+	OverflowDropOldest
+	// reject the new message with ErrQueueOverflow
+	OverflowError
+)
+
+var (
+	ErrQueueOverflow           = errors.New("websocket message queue is full")
+	ErrInvalidDispatcherConfig = errors.New("dispatcher workers and queue sizes should not be negative")
+	ErrDropOldestWithoutQueue  = errors.New("drop oldest overflow policy requires non-zero queue sizes")
+)
+
+// DispatcherConfig configures how the router runs handlers.
+// Messages of one topic and address are always handled by the same worker, so their order is kept
+type DispatcherConfig struct {
+	// Number of goroutines running handlers. Zero runs handlers synchronously inside of router
+	Workers int
+	// Buffer size of incoming messages queue
+	InputQueueSize int
+	// Buffer size of every worker queue
+	WorkerQueueSize int
+	OverflowPolicy  OverflowPolicy
+}
+
+// returns DispatcherConfig which runs handlers synchronously, blocking when input queue is full.
+// Pool of workers is opt-in, because it makes handlers of different topics and addresses concurrent
+func DefaultDispatcherConfig() DispatcherConfig {
+	return DispatcherConfig{
+		Workers:         0,
+		InputQueueSize:  1024,
+		WorkerQueueSize: 256,
+		OverflowPolicy:  OverflowBlock,
+	}
+}
+
+// returns ErrInvalidDispatcherConfig when workers or queue sizes are negative or overflow policy is unknown,
+// returns ErrDropOldestWithoutQueue when OverflowDropOldest is used with unbuffered queue, which has nothing to drop
+func (c DispatcherConfig) Validate() error {
+	if c.Workers < 0 || c.InputQueueSize < 0 || c.WorkerQueueSize < 0 || c.OverflowPolicy > OverflowError {
+		return ErrInvalidDispatcherConfig
+	}
+
+	if c.OverflowPolicy == OverflowDropOldest && (c.InputQueueSize == 0 || (c.Workers > 0 && c.WorkerQueueSize == 0)) {
+		return ErrDropOldestWithoutQueue
+	}
+
+	return nil
+}
+
+// DispatcherMetrics is a snapshot of router queues
+type DispatcherMetrics struct {
+	InputQueueDepth   int
+	InputQueueSize    int
+	WorkerQueueDepths []int
+	WorkerQueueSize   int
+	Routed            uint64
+	Handled           uint64
+	Dropped           uint64
+	Rejected          uint64
+}
+
+// returns sum of messages waiting in all queues
+func (m DispatcherMetrics) QueueDepth() int {
+	depth := m.InputQueueDepth
+	for _, d := range m.WorkerQueueDepths {
+		depth += d
+	}
+
+	return depth
+}
+
+func (m *DispatcherMetrics) add(other DispatcherMetrics) {
+	m.InputQueueDepth += other.InputQueueDepth
+	m.InputQueueSize += other.InputQueueSize
+	m.WorkerQueueDepths = append(m.WorkerQueueDepths, other.WorkerQueueDepths...)
+	m.WorkerQueueSize = other.WorkerQueueSize
+	m.Routed += other.Routed
+	m.Handled += other.Handled
+	m.Dropped += other.Dropped
+	m.Rejected += other.Rejected
+}
+
+// offer puts an item into a queue following the policy. trySend and tryDrop must not block
+func offer(policy OverflowPolicy, trySend func() bool, send func() bool, tryDrop func() bool) (dropped int, err error) {
+	switch policy {
+	case OverflowDropOldest:
+		for !trySend() {
+			if tryDrop() {
+				dropped++
+			}
+		}
+		return dropped, nil
+	case OverflowError:
+		if !trySend() {
+			return 0, ErrQueueOverflow
+		}
+		return 0, nil
+	default:
+		if !send() {
+			return 0, ErrQueueOverflow
+		}
+		return 0, nil
+	}
+}
+
+// returns worker index for topic and address, so messages with the same key are handled in order
+func workerIndex(key string, workers int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return int(h.Sum32() % uint32(workers))
+}
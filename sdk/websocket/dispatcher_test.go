@@ -0,0 +1,289 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func trySendFn(ch chan int, v int) func() bool {
+	return func() bool {
+		select {
+		case ch <- v:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func tryDropFn(ch chan int) func() bool {
+	return func() bool {
+		select {
+		case <-ch:
+			return true
+		default:
+			return false
+		}
+	}
+}
+
+func TestOffer(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		ch := make(chan int, 1)
+
+		_, err := offer(OverflowError, trySendFn(ch, 1), nil, tryDropFn(ch))
+		assert.Nil(t, err)
+
+		_, err = offer(OverflowError, trySendFn(ch, 2), nil, tryDropFn(ch))
+		assert.Equal(t, ErrQueueOverflow, err)
+		assert.Equal(t, 1, <-ch)
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		ch := make(chan int, 2)
+
+		for i := 1; i <= 4; i++ {
+			_, err := offer(OverflowDropOldest, trySendFn(ch, i), nil, tryDropFn(ch))
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, 3, <-ch)
+		assert.Equal(t, 4, <-ch)
+	})
+
+	t.Run("block", func(t *testing.T) {
+		ch := make(chan int, 1)
+		ch <- 1
+
+		done := make(chan struct{})
+		go func() {
+			_, err := offer(OverflowBlock, nil, func() bool { ch <- 2; return true }, nil)
+			assert.Nil(t, err)
+			close(done)
+		}()
+
+		assert.Equal(t, 1, <-ch)
+		<-done
+		assert.Equal(t, 2, <-ch)
+	})
+}
+
+type recordingHandler struct {
+	sync.Mutex
+	got []string
+	wg  sync.WaitGroup
+}
+
+func (h *recordingHandler) Handle(address *sdk.Address, data []byte) bool {
+	h.Lock()
+	h.got = append(h.got, address.Address+string(data))
+	h.Unlock()
+	h.wg.Done()
+
+	return true
+}
+
+type testMessageInfoMapper struct{}
+
+func (testMessageInfoMapper) MapMessageInfo(m []byte) (*sdk.WsMessageInfo, error) {
+	return &sdk.WsMessageInfo{ChannelName: "test", Address: &sdk.Address{Address: string(m[:1])}}, nil
+}
+
+// newTestRouter returns started router which takes address of message from its first byte
+func newTestRouter(publisher MessagePublisher, storage TopicHandlersStorage, cfg DispatcherConfig) *messageRouter {
+	r := &messageRouter{
+		uid:               "uid",
+		topicHandlers:     storage,
+		messageInfoMapper: testMessageInfoMapper{},
+		messagePublisher:  publisher,
+		dataCh:            make(chan []byte, cfg.InputQueueSize),
+		workers:           make([]chan *routedMessage, cfg.Workers),
+		policy:            cfg.OverflowPolicy,
+		doneCh:            make(chan struct{}),
+	}
+
+	for i := range r.workers {
+		r.workers[i] = make(chan *routedMessage, cfg.WorkerQueueSize)
+	}
+
+	r.start()
+
+	return r
+}
+
+func TestMessageRouter_OrderPerAddress(t *testing.T) {
+	handler := &recordingHandler{}
+
+	storage := &topicHandlers{h: make(topicHandlersMap)}
+	storage.SetTopicHandler("test", &TopicHandler{Handler: handler, Topic: topicFormatFn(formatPlainTopic)})
+
+	r := newTestRouter(new(MockMessagePublisher), storage, DispatcherConfig{
+		Workers:         4,
+		InputQueueSize:  16,
+		WorkerQueueSize: 16,
+		OverflowPolicy:  OverflowBlock,
+	})
+	defer r.Close()
+
+	messages := []string{"A1", "B1", "A2", "C1", "B2", "A3", "C2", "B3", "A4"}
+	handler.wg.Add(len(messages))
+	for _, m := range messages {
+		assert.Nil(t, r.RouteMessage([]byte(m)))
+	}
+	handler.wg.Wait()
+
+	byAddress := make(map[byte][]string)
+	for _, m := range handler.got {
+		byAddress[m[0]] = append(byAddress[m[0]], m[1:])
+	}
+
+	assert.Equal(t, []string{"A1", "A2", "A3", "A4"}, byAddress['A'])
+	assert.Equal(t, []string{"B1", "B2", "B3"}, byAddress['B'])
+	assert.Equal(t, []string{"C1", "C2"}, byAddress['C'])
+
+	metrics := r.Metrics()
+	assert.Equal(t, uint64(len(messages)), metrics.Routed)
+	assert.Equal(t, 4, len(metrics.WorkerQueueDepths))
+	assert.Equal(t, 16, metrics.InputQueueSize)
+	assert.Equal(t, 16, metrics.WorkerQueueSize)
+}
+
+func TestMessageRouter_Overflow(t *testing.T) {
+	// router is not started, so messages stay in the input queue
+	r := &messageRouter{
+		dataCh: make(chan []byte, 2),
+		policy: OverflowError,
+		doneCh: make(chan struct{}),
+	}
+	defer r.Close()
+
+	assert.Nil(t, r.RouteMessage([]byte("A1")))
+	assert.Nil(t, r.RouteMessage([]byte("A2")))
+	assert.Equal(t, ErrQueueOverflow, r.RouteMessage([]byte("A3")))
+
+	metrics := r.Metrics()
+	assert.Equal(t, 2, metrics.QueueDepth())
+	assert.Equal(t, uint64(1), metrics.Rejected)
+
+	r.policy = OverflowDropOldest
+	assert.Nil(t, r.RouteMessage([]byte("A4")))
+	assert.Equal(t, uint64(1), r.Metrics().Dropped)
+	assert.Equal(t, []byte("A2"), <-r.dataCh)
+	assert.Equal(t, []byte("A4"), <-r.dataCh)
+}
+
+func TestMessageRouter_UnsubscribeOnDoneHandler(t *testing.T) {
+	var unsubscribed sync.WaitGroup
+	unsubscribed.Add(1)
+
+	storage := &topicHandlers{h: make(topicHandlersMap)}
+	storage.SetTopicHandler("test", &TopicHandler{Handler: doneHandler{}, Topic: topicFormatFn(formatPlainTopic)})
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("PublishUnsubscribeMessage", "uid", Path("test/A")).Return(nil).Run(func(mock.Arguments) {
+		unsubscribed.Done()
+	})
+
+	r := newTestRouter(publisher, storage, DispatcherConfig{
+		Workers:         1,
+		InputQueueSize:  1,
+		WorkerQueueSize: 1,
+	})
+	defer r.Close()
+
+	assert.Nil(t, r.RouteMessage([]byte("A1")))
+
+	waitCh := make(chan struct{})
+	go func() {
+		unsubscribed.Wait()
+		close(waitCh)
+	}()
+
+	select {
+	case <-waitCh:
+	case <-time.After(time.Second):
+		t.Fatal("unsubscribe message was not published")
+	}
+}
+
+type doneHandler struct{}
+
+func (doneHandler) Handle(*sdk.Address, []byte) bool {
+	return false
+}
+
+func TestDispatcherConfig_Validate(t *testing.T) {
+	assert.Nil(t, DefaultDispatcherConfig().Validate())
+	assert.Equal(t, 0, DefaultDispatcherConfig().Workers)
+	assert.Nil(t, DispatcherConfig{}.Validate())
+	assert.Nil(t, DispatcherConfig{InputQueueSize: 1, OverflowPolicy: OverflowDropOldest}.Validate())
+
+	for _, cfg := range []DispatcherConfig{
+		{Workers: -1},
+		{InputQueueSize: -1},
+		{WorkerQueueSize: -1},
+		{OverflowPolicy: OverflowError + 1},
+	} {
+		assert.Equal(t, ErrInvalidDispatcherConfig, cfg.Validate())
+
+		_, err := NewRouterWithConfig("uid", new(MockMessagePublisher), &topicHandlers{h: make(topicHandlersMap)}, cfg)
+		assert.Equal(t, ErrInvalidDispatcherConfig, err)
+	}
+
+	for _, cfg := range []DispatcherConfig{
+		{OverflowPolicy: OverflowDropOldest},
+		{Workers: 2, InputQueueSize: 1, OverflowPolicy: OverflowDropOldest},
+		{Workers: 2, WorkerQueueSize: 1, OverflowPolicy: OverflowDropOldest},
+	} {
+		assert.Equal(t, ErrDropOldestWithoutQueue, cfg.Validate())
+
+		_, err := NewRouterWithConfig("uid", new(MockMessagePublisher), &topicHandlers{h: make(topicHandlersMap)}, cfg)
+		assert.Equal(t, ErrDropOldestWithoutQueue, err)
+	}
+}
+
+func TestCatapultWebsocketClientImpl_ReconnectKeepsRouter(t *testing.T) {
+	// router is not started, so the message read from previous connection stays queued
+	r := &messageRouter{
+		uid:    "old-uid",
+		dataCh: make(chan []byte, 1),
+		doneCh: make(chan struct{}),
+	}
+	defer r.Close()
+	assert.Nil(t, r.RouteMessage([]byte("A1")))
+
+	publisher := new(MockMessagePublisher)
+	publisher.On("SetConn", mock.Anything).Return()
+
+	c := &CatapultWebsocketClientImpl{
+		messageRouter:    r,
+		messagePublisher: publisher,
+		connectFn: func(*sdk.Config) (*websocket.Conn, string, error) {
+			return nil, "new-uid", nil
+		},
+	}
+
+	assert.Nil(t, c.initNewConnection())
+	assert.Equal(t, "new-uid", c.UID)
+	assert.Equal(t, Router(r), c.messageRouter)
+	assert.Equal(t, "new-uid", r.getUid())
+	assert.Equal(t, 1, r.Metrics().InputQueueDepth)
+	publisher.AssertCalled(t, "SetConn", mock.Anything)
+
+	select {
+	case <-r.doneCh:
+		t.Fatal("router was closed on reconnect")
+	default:
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"strings"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+type (
+	// TransactionFilter reports whether a single transaction is interesting for a handler.
+	// Filters are applied to the transaction itself and to every inner transaction of an aggregate,
+	// so transaction passes when at least one of them satisfies the filter
+	TransactionFilter func(sdk.Transaction) bool
+
+	// Typed handlers for confirmed transactions. Returning true removes the handler, like for all other handlers
+	TransferHandler    func(*sdk.TransferTransaction) bool
+	LockFundsHandler   func(*sdk.LockFundsTransaction) bool
+	SecretLockHandler  func(*sdk.SecretLockTransaction) bool
+	SecretProofHandler func(*sdk.SecretProofTransaction) bool
+)
+
+// returns TransactionFilter which passes transactions of one of passed EntityType's
+func FilterByEntityType(types ...sdk.EntityType) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		txType := tx.GetAbstractTransaction().Type
+		for _, t := range types {
+			if txType == t {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// returns TransactionFilter which passes transactions carrying one of passed AssetId's.
+// Asset ids are compared as is, so namespace aliases are not resolved
+func FilterByMosaic(assetIds ...sdk.AssetId) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		for _, m := range transactionMosaics(tx) {
+			for _, id := range assetIds {
+				if sameAssetId(m.AssetId, id) {
+					return true
+				}
+			}
+		}
+
+		return false
+	}
+}
+
+// returns TransactionFilter which passes transactions carrying at least passed amount of AssetId
+func FilterByMinAmount(assetId sdk.AssetId, amount sdk.Amount) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		for _, m := range transactionMosaics(tx) {
+			if sameAssetId(m.AssetId, assetId) && m.Amount >= amount {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// returns TransactionFilter which passes transactions signed by passed PublicAccount
+func FilterBySigner(signer *sdk.PublicAccount) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		s := tx.GetAbstractTransaction().Signer
+		return s != nil && signer != nil && strings.EqualFold(s.PublicKey, signer.PublicKey)
+	}
+}
+
+// returns TransactionFilter which passes transactions sent to passed Address
+func FilterByRecipient(recipient *sdk.Address) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		r := transactionRecipient(tx)
+		return r != nil && recipient != nil && r.Address == recipient.Address
+	}
+}
+
+// returns TransactionFilter which passes transactions satisfying every passed filter
+func AllOf(filters ...TransactionFilter) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		for _, f := range filters {
+			if !f(tx) {
+				return false
+			}
+		}
+
+		return true
+	}
+}
+
+// returns TransactionFilter which passes transactions satisfying at least one of passed filters
+func AnyOf(filters ...TransactionFilter) TransactionFilter {
+	return func(tx sdk.Transaction) bool {
+		for _, f := range filters {
+			if f(tx) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Match checks transaction and every inner transaction of aggregate against filter
+func (f TransactionFilter) Match(tx sdk.Transaction) bool {
+	if f == nil {
+		return true
+	}
+
+	if f(tx) {
+		return true
+	}
+
+	if agg, ok := tx.(*sdk.AggregateTransaction); ok {
+		for _, inner := range agg.InnerTransactions {
+			if f(inner) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// returns transaction and inner transactions of aggregate in one list
+func flattenTransaction(tx sdk.Transaction) []sdk.Transaction {
+	if agg, ok := tx.(*sdk.AggregateTransaction); ok {
+		return append([]sdk.Transaction{tx}, agg.InnerTransactions...)
+	}
+
+	return []sdk.Transaction{tx}
+}
+
+func transactionMosaics(tx sdk.Transaction) []*sdk.Mosaic {
+	switch t := tx.(type) {
+	case *sdk.TransferTransaction:
+		return t.Mosaics
+	case *sdk.LockFundsTransaction:
+		if t.Mosaic != nil {
+			return []*sdk.Mosaic{t.Mosaic}
+		}
+	case *sdk.SecretLockTransaction:
+		if t.Mosaic != nil {
+			return []*sdk.Mosaic{t.Mosaic}
+		}
+	}
+
+	return nil
+}
+
+func transactionRecipient(tx sdk.Transaction) *sdk.Address {
+	switch t := tx.(type) {
+	case *sdk.TransferTransaction:
+		return t.Recipient
+	case *sdk.SecretLockTransaction:
+		return t.Recipient
+	case *sdk.SecretProofTransaction:
+		return t.Recipient
+	}
+
+	return nil
+}
+
+func sameAssetId(left, right sdk.AssetId) bool {
+	if left == nil || right == nil {
+		return false
+	}
+
+	return left.Type() == right.Type() && left.Id() == right.Id()
+}
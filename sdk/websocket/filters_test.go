@@ -0,0 +1,121 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"testing"
+
+	mocks "github.com/proximax-storage/go-xpx-chain-sdk/mocks/websocket/subscribers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+const (
+	filterSignerKey    = "321DE652C4D3362FC2DDF7800F6582F4A10CFEA134B81F8AB6E4BE78BBA4D18E"
+	filterRecipientKey = "F3BBEBD2BB8A27C2A0E5C68A87AA7BB9D1D0E7D6FD0A9EC4B4E9EA6A4B2E5F9A"
+)
+
+func newFilterTransfer(t *testing.T, recipient *sdk.Address, amount sdk.Amount) *sdk.TransferTransaction {
+	tx, err := sdk.NewTransferTransaction(
+		sdk.NewDeadline(0),
+		recipient,
+		[]*sdk.Mosaic{sdk.Xpx(uint64(amount))},
+		sdk.NewPlainMessage(""),
+		sdk.MijinTest,
+	)
+	assert.Nil(t, err)
+
+	signer, err := sdk.NewAccountFromPublicKey(filterSignerKey, sdk.MijinTest)
+	assert.Nil(t, err)
+	tx.Signer = signer
+
+	return tx
+}
+
+func TestTransactionFilter_Match(t *testing.T) {
+	signer, err := sdk.NewAccountFromPublicKey(filterSignerKey, sdk.MijinTest)
+	assert.Nil(t, err)
+	recipient, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	transfer := newFilterTransfer(t, recipient.Address, 100)
+
+	aggregate, err := sdk.NewCompleteAggregateTransaction(sdk.NewDeadline(0), []sdk.Transaction{transfer}, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name   string
+		filter TransactionFilter
+		tx     sdk.Transaction
+		want   bool
+	}{
+		{"nil filter", nil, transfer, true},
+		{"entity type", FilterByEntityType(sdk.Transfer), transfer, true},
+		{"other entity type", FilterByEntityType(sdk.Lock, sdk.SecretLock), transfer, false},
+		{"mosaic", FilterByMosaic(sdk.XpxNamespaceId), transfer, true},
+		{"other mosaic", FilterByMosaic(sdk.StorageNamespaceId), transfer, false},
+		{"min amount", FilterByMinAmount(sdk.XpxNamespaceId, 100), transfer, true},
+		{"below min amount", FilterByMinAmount(sdk.XpxNamespaceId, 101), transfer, false},
+		{"signer", FilterBySigner(signer), transfer, true},
+		{"other signer", FilterBySigner(recipient), transfer, false},
+		{"recipient", FilterByRecipient(recipient.Address), transfer, true},
+		{"other recipient", FilterByRecipient(signer.Address), transfer, false},
+		{"inner transaction of aggregate", FilterByEntityType(sdk.Transfer), aggregate, true},
+		{"aggregate itself", FilterByEntityType(sdk.AggregateCompleted), aggregate, true},
+		{"all of inner", AllOf(FilterByEntityType(sdk.Transfer), FilterByMinAmount(sdk.XpxNamespaceId, 50)), aggregate, true},
+		{"all of failed", AllOf(FilterByEntityType(sdk.Transfer), FilterByMinAmount(sdk.XpxNamespaceId, 500)), aggregate, false},
+		{"any of", AnyOf(FilterByEntityType(sdk.Lock), FilterByRecipient(recipient.Address)), transfer, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Match(tt.tx))
+		})
+	}
+}
+
+func TestCatapultWebsocketClientImpl_OnTransfer(t *testing.T) {
+	recipient, err := sdk.NewAccountFromPublicKey(filterRecipientKey, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	transfer := newFilterTransfer(t, recipient.Address, 100)
+	lock, err := sdk.NewSecretLockTransaction(sdk.NewDeadline(0), sdk.Xpx(10), 10, &sdk.Secret{}, recipient.Address, sdk.MijinTest)
+	assert.Nil(t, err)
+	aggregate, err := sdk.NewCompleteAggregateTransaction(sdk.NewDeadline(0), []sdk.Transaction{lock, transfer}, sdk.MijinTest)
+	assert.Nil(t, err)
+
+	var registered subscribers.ConfirmedAddedHandler
+
+	mockTopicHandler := new(MockTopicHandlersStorage)
+	mockTopicHandler.On("HasHandler", mock.Anything).Return(true)
+
+	mockSubscribers := new(mocks.ConfirmedAdded)
+	mockSubscribers.On("HasHandlers", recipient.Address).Return(true).
+		On("AddHandlers", recipient.Address, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) {
+			registered = args.Get(1).(subscribers.ConfirmedAddedHandler)
+		})
+
+	c := &CatapultWebsocketClientImpl{
+		confirmedAddedSubscribers: mockSubscribers,
+		topicHandlers:             mockTopicHandler,
+		config:                    &sdk.Config{},
+	}
+
+	var got []*sdk.TransferTransaction
+	err = c.OnTransfer(recipient.Address, func(tx *sdk.TransferTransaction) bool {
+		got = append(got, tx)
+		return false
+	})
+	assert.Nil(t, err)
+	assert.NotNil(t, registered)
+
+	assert.False(t, registered(lock))
+	assert.False(t, registered(transfer))
+	assert.False(t, registered(aggregate))
+	assert.Equal(t, []*sdk.TransferTransaction{transfer, transfer}, got)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+// CustomDecoder decodes raw websocket message of custom topic
+type CustomDecoder func([]byte) (interface{}, error)
+
+func NewCustomHandler(decoder CustomDecoder, handlers subscribers.Custom) *customHandler {
+	return &customHandler{
+		decoder:  decoder,
+		handlers: handlers,
+	}
+}
+
+type customHandler struct {
+	decoder  CustomDecoder
+	handlers subscribers.Custom
+}
+
+func (h *customHandler) Handle(address *sdk.Address, resp []byte) bool {
+	handlers := h.handlers.GetHandlers(address)
+	if len(handlers) == 0 {
+		return true
+	}
+
+	res, err := h.decoder(resp)
+	if err != nil {
+		// decoders are provided by users, so broken message must not stop the client
+		fmt.Println(err, "decoding custom topic message")
+		return true
+	}
+
+	var wg sync.WaitGroup
+
+	for _, f := range handlers {
+		wg.Add(1)
+		go func(f *subscribers.CustomHandler) {
+			defer wg.Done()
+
+			callFunc := *f
+
+			if rm := callFunc(res); !rm {
+				return
+			}
+
+			h.handlers.RemoveHandlers(address, f)
+		}(f)
+	}
+
+	wg.Wait()
+
+	return h.handlers.HasHandlers(address)
+}
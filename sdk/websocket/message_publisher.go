@@ -1,6 +1,7 @@
 package websocket
 
 import (
+	"encoding/json"
 	"sync"
 
 	"github.com/gorilla/websocket"
@@ -16,6 +17,8 @@ func newMessagePublisher(conn *websocket.Conn) MessagePublisher {
 type MessagePublisher interface {
 	PublishSubscribeMessage(uid string, path Path) error
 	PublishUnsubscribeMessage(uid string, path Path) error
+	PublishSubscribeMessages(uid string, paths ...Path) error
+	PublishUnsubscribeMessages(uid string, paths ...Path) error
 	SetConn(conn *websocket.Conn)
 }
 
@@ -56,7 +59,60 @@ func (p *catapultWebsocketMessagePublisher) PublishUnsubscribeMessage(uid string
 	return nil
 }
 
+// PublishSubscribeMessages encodes subscribe messages for all paths beforehand and writes them while holding the connection once,
+// so other writers don't interleave with a big batch. Catapult accepts only one topic per subscribe message
+func (p *catapultWebsocketMessagePublisher) PublishSubscribeMessages(uid string, paths ...Path) error {
+	messages := make([][]byte, len(paths))
+	for i, path := range paths {
+		b, err := json.Marshal(&subscribeDTO{
+			Uid:       uid,
+			Subscribe: string(path),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "encoding subscribe message for %s", path)
+		}
+
+		messages[i] = b
+	}
+
+	return p.writeMessages(paths, messages)
+}
+
+// PublishUnsubscribeMessages encodes unsubscribe messages for all paths beforehand and writes them while holding the connection once
+func (p *catapultWebsocketMessagePublisher) PublishUnsubscribeMessages(uid string, paths ...Path) error {
+	messages := make([][]byte, len(paths))
+	for i, path := range paths {
+		b, err := json.Marshal(&unsubscribeDTO{
+			Uid:         uid,
+			Unsubscribe: string(path),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "encoding unsubscribe message for %s", path)
+		}
+
+		messages[i] = b
+	}
+
+	return p.writeMessages(paths, messages)
+}
+
+func (p *catapultWebsocketMessagePublisher) writeMessages(paths []Path, messages [][]byte) error {
+	p.Lock()
+	defer p.Unlock()
+
+	for i, m := range messages {
+		if err := p.conn.WriteMessage(websocket.TextMessage, m); err != nil {
+			return errors.Wrapf(err, "publishing message for %s into websocket connection", paths[i])
+		}
+	}
+
+	return nil
+}
+
 func (p *catapultWebsocketMessagePublisher) SetConn(conn *websocket.Conn) {
+	p.Lock()
+	defer p.Unlock()
+
 	p.conn = conn
 }
 
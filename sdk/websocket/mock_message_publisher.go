@@ -44,6 +44,48 @@ func (_m *MockMessagePublisher) PublishUnsubscribeMessage(uid string, path Path)
 	return r0
 }
 
+// PublishSubscribeMessages provides a mock function with given fields: uid, paths
+func (_m *MockMessagePublisher) PublishSubscribeMessages(uid string, paths ...Path) error {
+	_va := make([]interface{}, len(paths))
+	for _i := range paths {
+		_va[_i] = paths[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, uid)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...Path) error); ok {
+		r0 = rf(uid, paths...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PublishUnsubscribeMessages provides a mock function with given fields: uid, paths
+func (_m *MockMessagePublisher) PublishUnsubscribeMessages(uid string, paths ...Path) error {
+	_va := make([]interface{}, len(paths))
+	for _i := range paths {
+		_va[_i] = paths[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, uid)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, ...Path) error); ok {
+		r0 = rf(uid, paths...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetConn provides a mock function with given fields: conn
 func (_m *MockMessagePublisher) SetConn(conn *websocket.Conn) {
 	_m.Called(conn)
@@ -0,0 +1,122 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+const partialInboxErrorsBufferSize = 16
+
+type partialHandlersAdder interface {
+	AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error
+	AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error
+	AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error
+}
+
+// WatchPartialInbox keeps inbox updated from partialAdded, cosignature and partialRemoved topics of its account until ctx is done.
+// Inbox is refreshed from REST after subscription, updates received during refresh are applied on top of it.
+// Errors of updates are sent to returned channel, they are dropped when nobody reads them.
+// The channel is closed when ctx is done. Passed websocket client should be listened by caller
+func WatchPartialInbox(ctx context.Context, ws CatapultClient, inbox *sdk.PartialInbox) (<-chan error, error) {
+	return watchPartialInbox(ctx, ws, inbox)
+}
+
+func watchPartialInbox(ctx context.Context, ws partialHandlersAdder, inbox *sdk.PartialInbox) (<-chan error, error) {
+	errs := newErrorSink(partialInboxErrorsBufferSize)
+	address := inbox.Account.Address
+
+	err := ws.AddPartialAddedHandlers(address, func(tx *sdk.AggregateTransaction) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		if _, err := inbox.AddPartial(ctx, tx); err != nil {
+			errs.send(errors.Wrap(err, "adding partial"))
+		}
+		return false
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribing to partial added")
+	}
+
+	err = ws.AddCosignatureHandlers(address, func(info *sdk.SignerInfo) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		if _, err := inbox.AddCosignature(info); err != nil {
+			errs.send(errors.Wrap(err, "adding cosignature"))
+		}
+		return false
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribing to cosignatures")
+	}
+
+	err = ws.AddPartialRemovedHandlers(address, func(info *sdk.PartialRemovedInfo) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+
+		if info.Meta != nil {
+			inbox.RemovePartial(info.Meta.TransactionHash)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "subscribing to partial removed")
+	}
+
+	if err = inbox.Refresh(ctx); err != nil {
+		return nil, errors.Wrap(err, "refreshing partial inbox")
+	}
+
+	go func() {
+		<-ctx.Done()
+		errs.close()
+	}()
+
+	return errs.ch, nil
+}
+
+// errorSink is a buffered channel of errors which can be sent to after it is closed
+type errorSink struct {
+	sync.Mutex
+	ch     chan error
+	closed bool
+}
+
+func newErrorSink(size int) *errorSink {
+	return &errorSink{ch: make(chan error, size)}
+}
+
+func (s *errorSink) send(err error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.ch <- err:
+	default:
+	}
+}
+
+func (s *errorSink) close() {
+	s.Lock()
+	defer s.Unlock()
+
+	s.closed = true
+	close(s.ch)
+}
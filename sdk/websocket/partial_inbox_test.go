@@ -0,0 +1,99 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+type fakePartialInboxSource struct {
+	partials []*sdk.AggregateTransaction
+}
+
+func (s *fakePartialInboxSource) AggregateBondedTransactions(context.Context, *sdk.PublicAccount, *sdk.AccountTransactionsOption) ([]*sdk.AggregateTransaction, error) {
+	return s.partials, nil
+}
+
+func (s *fakePartialInboxSource) GetMultisigAccountGraphInfo(context.Context, *sdk.Address) (*sdk.MultisigAccountGraphInfo, error) {
+	return nil, sdk.ErrResourceNotFound
+}
+
+func (s *fakePartialInboxSource) GetHashLockInfo(context.Context, *sdk.Hash) (*sdk.HashLockInfo, error) {
+	return nil, sdk.ErrResourceNotFound
+}
+
+type fakePartialHandlersAdder struct {
+	added   subscribers.PartialAddedHandler
+	removed subscribers.PartialRemovedHandler
+	cosign  subscribers.CosignatureHandler
+}
+
+func (a *fakePartialHandlersAdder) AddPartialAddedHandlers(_ *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
+	a.added = handlers[0]
+	return nil
+}
+
+func (a *fakePartialHandlersAdder) AddPartialRemovedHandlers(_ *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error {
+	a.removed = handlers[0]
+	return nil
+}
+
+func (a *fakePartialHandlersAdder) AddCosignatureHandlers(_ *sdk.Address, handlers ...subscribers.CosignatureHandler) error {
+	a.cosign = handlers[0]
+	return nil
+}
+
+func testPartial(hash *sdk.Hash, signer, innerSigner *sdk.PublicAccount) *sdk.AggregateTransaction {
+	inner := &sdk.TransferTransaction{}
+	inner.Signer = innerSigner
+
+	tx := &sdk.AggregateTransaction{InnerTransactions: []sdk.Transaction{inner}}
+	tx.Signer = signer
+	tx.TransactionInfo.TransactionHash = hash
+
+	return tx
+}
+
+func TestWatchPartialInbox(t *testing.T) {
+	account, err := sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nil(t, err)
+	cosigner, err := sdk.NewAccount(sdk.MijinTest, nil)
+	assert.Nil(t, err)
+
+	source := &fakePartialInboxSource{partials: []*sdk.AggregateTransaction{testPartial(&sdk.Hash{1}, account.PublicAccount, account.PublicAccount)}}
+	inbox := sdk.NewPartialInbox(account.PublicAccount, source, nil)
+	ws := &fakePartialHandlersAdder{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs, err := watchPartialInbox(ctx, ws, inbox)
+	assert.Nil(t, err)
+	assert.Len(t, inbox.Partials(), 1)
+
+	assert.False(t, ws.added(testPartial(&sdk.Hash{2}, account.PublicAccount, cosigner.PublicAccount)))
+	assert.False(t, inbox.Partial(&sdk.Hash{2}).Complete)
+
+	assert.False(t, ws.cosign(&sdk.SignerInfo{Signer: cosigner.PublicAccount.PublicKey, ParentHash: &sdk.Hash{2}}))
+	assert.True(t, inbox.Partial(&sdk.Hash{2}).Complete)
+
+	assert.False(t, ws.removed(&sdk.PartialRemovedInfo{Meta: &sdk.TransactionInfo{TransactionHash: &sdk.Hash{1}}}))
+	assert.Nil(t, inbox.Partial(&sdk.Hash{1}))
+
+	// partial without hash can't be tracked
+	assert.False(t, ws.added(&sdk.AggregateTransaction{}))
+	assert.NotNil(t, <-errs)
+
+	cancel()
+	assert.True(t, ws.added(testPartial(&sdk.Hash{3}, account.PublicAccount, cosigner.PublicAccount)))
+	assert.Nil(t, inbox.Partial(&sdk.Hash{3}))
+
+	for range errs {
+	}
+}
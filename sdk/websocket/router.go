@@ -3,71 +3,254 @@ package websocket
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/pkg/errors"
 	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
 
 	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
 	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/handlers"
 )
 
 func NewRouter(uid string, publisher MessagePublisher, topicHandlers TopicHandlersStorage) Router {
+	// default config is always valid
+	router, _ := NewRouterWithConfig(uid, publisher, topicHandlers, DefaultDispatcherConfig())
+	return router
+}
+
+// returns Router which runs handlers in pool of workers configured by DispatcherConfig
+func NewRouterWithConfig(uid string, publisher MessagePublisher, topicHandlers TopicHandlersStorage, cfg DispatcherConfig) (Router, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	router := messageRouter{
 		uid:               uid,
 		topicHandlers:     topicHandlers,
 		messageInfoMapper: messageInfoMapperFn(MapMessageInfo),
 		messagePublisher:  publisher,
-		dataCh:            make(chan []byte, 1024),
+		dataCh:            make(chan []byte, cfg.InputQueueSize),
+		policy:            cfg.OverflowPolicy,
+		doneCh:            make(chan struct{}),
 	}
 
-	// TODO: Close channel
-	go router.run()
+	router.workers = make([]chan *routedMessage, cfg.Workers)
+	for i := range router.workers {
+		router.workers[i] = make(chan *routedMessage, cfg.WorkerQueueSize)
+	}
+
+	router.start()
 
-	return &router
+	return &router, nil
 }
 
 type Router interface {
-	RouteMessage([]byte)
+	RouteMessage([]byte) error
 	SetUid(string)
+	Metrics() DispatcherMetrics
+	Close()
 }
 
 type messageRouter struct {
+	uidLock           sync.RWMutex
 	uid               string
 	messagePublisher  MessagePublisher
 	messageInfoMapper MessageInfoMapper
 	topicHandlers     TopicHandlersStorage
 	dataCh            chan []byte
+	workers           []chan *routedMessage
+	policy            OverflowPolicy
+	doneCh            chan struct{}
+	closeOnce         sync.Once
+
+	routed   uint64
+	handled  uint64
+	dropped  uint64
+	rejected uint64
+}
+
+type routedMessage struct {
+	info    *sdk.WsMessageInfo
+	handler *TopicHandler
+	data    []byte
+}
+
+func (r *messageRouter) start() {
+	for _, queue := range r.workers {
+		go r.runWorker(queue)
+	}
+
+	go r.run()
 }
 
 func (r *messageRouter) run() {
-	for m := range r.dataCh {
-		messageInfo, err := r.messageInfoMapper.MapMessageInfo(m)
-		if err != nil {
-			panic(errors.Wrap(err, "getting message info"))
+	for {
+		select {
+		case <-r.doneCh:
+			return
+		case m := <-r.dataCh:
+			r.route(m)
 		}
+	}
+}
 
-		handler := r.topicHandlers.GetHandler(Path(messageInfo.ChannelName))
-		if handler == nil {
-			fmt.Println("getting topic handler from topic handlers storage")
-			continue
+func (r *messageRouter) runWorker(queue chan *routedMessage) {
+	for {
+		select {
+		case <-r.doneCh:
+			return
+		case m := <-queue:
+			r.handle(m)
 		}
+	}
+}
+
+// route finds the handler of message topic and passes message to it
+func (r *messageRouter) route(m []byte) {
+	messageInfo, err := r.messageInfoMapper.MapMessageInfo(m)
+	if err != nil {
+		panic(errors.Wrap(err, "getting message info"))
+	}
+
+	handler := r.topicHandlers.GetHandler(Path(messageInfo.ChannelName))
+	if handler == nil {
+		fmt.Println("getting topic handler from topic handlers storage")
+		return
+	}
+
+	atomic.AddUint64(&r.routed, 1)
 
-		if ok := handler.Handle(messageInfo.Address, m); !ok {
-			if err := r.messagePublisher.PublishUnsubscribeMessage(r.uid, Path(handler.Format(messageInfo))); err != nil {
-				fmt.Println(err, "unsubscribing from topic")
-				continue
+	msg := &routedMessage{info: messageInfo, handler: handler, data: m}
+	if len(r.workers) == 0 {
+		r.handle(msg)
+		return
+	}
+
+	queue := r.workers[workerIndex(messageKey(messageInfo), len(r.workers))]
+	dropped, err := offer(
+		r.policy,
+		func() bool {
+			select {
+			case queue <- msg:
+				return true
+			default:
+				return false
+			}
+		},
+		func() bool {
+			select {
+			case queue <- msg:
+				return true
+			case <-r.doneCh:
+				return false
 			}
+		},
+		func() bool {
+			select {
+			case <-queue:
+				return true
+			default:
+				return false
+			}
+		},
+	)
+
+	atomic.AddUint64(&r.dropped, uint64(dropped))
+	if err != nil {
+		atomic.AddUint64(&r.rejected, 1)
+		fmt.Println(err, "dispatching message to handler of", messageKey(messageInfo))
+	}
+}
+
+func (r *messageRouter) handle(m *routedMessage) {
+	defer atomic.AddUint64(&r.handled, 1)
+
+	if ok := m.handler.Handle(m.info.Address, m.data); !ok {
+		if err := r.messagePublisher.PublishUnsubscribeMessage(r.getUid(), Path(m.handler.Format(m.info))); err != nil {
+			fmt.Println(err, "unsubscribing from topic")
 		}
 	}
 }
 
-func (r *messageRouter) RouteMessage(m []byte) {
-	r.dataCh <- m
+// RouteMessage queues message for routing. With OverflowError policy full queue returns ErrQueueOverflow
+func (r *messageRouter) RouteMessage(m []byte) error {
+	dropped, err := offer(
+		r.policy,
+		func() bool {
+			select {
+			case r.dataCh <- m:
+				return true
+			default:
+				return false
+			}
+		},
+		func() bool {
+			select {
+			case r.dataCh <- m:
+				return true
+			case <-r.doneCh:
+				return false
+			}
+		},
+		func() bool {
+			select {
+			case <-r.dataCh:
+				return true
+			default:
+				return false
+			}
+		},
+	)
+
+	atomic.AddUint64(&r.dropped, uint64(dropped))
+	if err != nil {
+		atomic.AddUint64(&r.rejected, 1)
+	}
+
+	return err
+}
+
+func (r *messageRouter) Metrics() DispatcherMetrics {
+	m := DispatcherMetrics{
+		InputQueueDepth:   len(r.dataCh),
+		InputQueueSize:    cap(r.dataCh),
+		WorkerQueueDepths: make([]int, len(r.workers)),
+		Routed:            atomic.LoadUint64(&r.routed),
+		Handled:           atomic.LoadUint64(&r.handled),
+		Dropped:           atomic.LoadUint64(&r.dropped),
+		Rejected:          atomic.LoadUint64(&r.rejected),
+	}
+
+	for i, w := range r.workers {
+		m.WorkerQueueDepths[i] = len(w)
+		m.WorkerQueueSize = cap(w)
+	}
+
+	return m
+}
+
+// Close stops router and its workers. Queued messages are discarded
+func (r *messageRouter) Close() {
+	r.closeOnce.Do(func() {
+		close(r.doneCh)
+	})
 }
 
+// SetUid changes uid of connection used by workers, so router can outlive connection
 func (r *messageRouter) SetUid(uid string) {
+	r.uidLock.Lock()
+	defer r.uidLock.Unlock()
+
 	r.uid = uid
 }
 
+func (r *messageRouter) getUid() string {
+	r.uidLock.RLock()
+	defer r.uidLock.RUnlock()
+
+	return r.uid
+}
+
 func MapMessageInfo(m []byte) (*sdk.WsMessageInfo, error) {
 	var messageInfoDTO sdk.WsMessageInfoDTO
 	if err := json.Unmarshal(m, &messageInfoDTO); err != nil {
@@ -143,6 +326,15 @@ func formatPlainTopic(info *sdk.WsMessageInfo) Path {
 	return Path(fmt.Sprintf("%s/%s", Path(info.ChannelName), info.Address.Address))
 }
 
+// returns key of message ordering: topic with address
+func messageKey(info *sdk.WsMessageInfo) string {
+	if info.Address == nil {
+		return info.ChannelName
+	}
+
+	return info.ChannelName + "/" + info.Address.Address
+}
+
 func formatBlockTopic(_ *sdk.WsMessageInfo) Path {
 	return pathBlock
 }
@@ -0,0 +1,107 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	hdlrs "github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/handlers"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+const (
+	routerTestAddress = "90534434E016CAA132AB5EAC70C0AF7DF043B990C789A93EB1"
+
+	routerTestMessage = `{
+	"meta": {
+		"channelName": "confirmedAdded",
+		"address": "90534434E016CAA132AB5EAC70C0AF7DF043B990C789A93EB1",
+		"height": [42, 0],
+		"hash": "45AC1259DABD7163B2816232773E66FC00342BB8DD5C965D4B784CD575FDFAF1",
+		"merkleComponentHash": "45AC1259DABD7163B2816232773E66FC00342BB8DD5C965D4B784CD575FDFAF1",
+		"index": 0,
+		"id": "5B686E97F0C0EA00017B9437"
+	},
+	"transaction": {
+		"signature": "ADF80CBC864B65A8D94205E9EC6640FA4AE0E3011B27F8A93D93761E454A9853BF0AB1ECB3DF62E1D2D267D3F1913FAB0E2225CE5EA3937790B78FFA1288870C",
+		"signer": "27F6BEF9A7F75E33AE2EB2EBA10EF1D6BEA4D30EBD5E39AF8EE06E96E11AE2A9",
+		"version": -1879048189,
+		"type": 16724,
+		"maxFee": [1, 0],
+		"deadline": [1094650402, 17],
+		"recipient": "90534434E016CAA132AB5EAC70C0AF7DF043B990C789A93EB1",
+		"message": {"type": 0, "payload": ""},
+		"mosaics": [{"id": [3646934825, 3576016193], "amount": [10000000, 0]}]
+	}
+}`
+)
+
+// newBenchmarkRouter returns router with confirmedAdded handlers registered for watchedCount addresses
+func newBenchmarkRouter(b *testing.B, watchedCount int) *messageRouter {
+	target, err := sdk.NewAddressFromBase32(routerTestAddress)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	confirmedAdded := subscribers.NewConfirmedAdded()
+	handler := func(sdk.Transaction) bool { return false }
+
+	for i := 0; i < watchedCount-1; i++ {
+		if err := confirmedAdded.AddHandlers(&sdk.Address{Address: fmt.Sprintf("WATCHED%033d", i)}, handler); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	if err := confirmedAdded.AddHandlers(target, handler); err != nil {
+		b.Fatal(err)
+	}
+
+	// handlers are added asynchronously
+	for !confirmedAdded.HasHandlers(target) {
+	}
+
+	storage := &topicHandlers{h: make(topicHandlersMap)}
+	storage.SetTopicHandler(pathConfirmedAdded, &TopicHandler{
+		Handler: hdlrs.NewConfirmedAddedHandler(sdk.NewConfirmedAddedMapper(sdk.MapTransaction, &sdk.Hash{}), confirmedAdded),
+		Topic:   topicFormatFn(formatPlainTopic),
+	})
+
+	return &messageRouter{
+		topicHandlers:     storage,
+		messageInfoMapper: messageInfoMapperFn(MapMessageInfo),
+		messagePublisher:  new(MockMessagePublisher),
+	}
+}
+
+func BenchmarkMessageRouter_route(b *testing.B) {
+	message := []byte(routerTestMessage)
+
+	for _, watched := range []int{1, 1000, 10000, 50000} {
+		b.Run(fmt.Sprintf("addresses=%d", watched), func(b *testing.B) {
+			router := newBenchmarkRouter(b, watched)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				router.route(message)
+			}
+		})
+	}
+}
+
+func BenchmarkMapMessageInfo(b *testing.B) {
+	message := []byte(routerTestMessage)
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := MapMessageInfo(message); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
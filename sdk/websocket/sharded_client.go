@@ -0,0 +1,249 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk/websocket/subscribers"
+)
+
+var ErrInvalidShardsCount = errors.New("shards count should be greater than 0")
+
+// ShardedClient spreads address subscriptions over several websocket connections.
+// Every address is always served by the same connection, block handlers live on the first one
+type ShardedClient struct {
+	ctx        context.Context
+	cancelFunc context.CancelFunc
+
+	config *sdk.Config
+	shards []CatapultClient
+}
+
+// returns CatapultClient which opens shardsCount websocket connections and routes every address to one of them
+func NewShardedClient(ctx context.Context, cfg *sdk.Config, shardsCount int) (CatapultClient, error) {
+	return NewShardedClientWithDispatcherConfig(ctx, cfg, shardsCount, DefaultDispatcherConfig())
+}
+
+// returns ShardedClient whose every shard runs handlers in own pool of workers configured by DispatcherConfig
+func NewShardedClientWithDispatcherConfig(ctx context.Context, cfg *sdk.Config, shardsCount int, dispatcherCfg DispatcherConfig) (CatapultClient, error) {
+	if shardsCount <= 0 {
+		return nil, ErrInvalidShardsCount
+	}
+
+	ctx, cancelFunc := context.WithCancel(ctx)
+
+	c := &ShardedClient{
+		ctx:        ctx,
+		cancelFunc: cancelFunc,
+		config:     cfg,
+		shards:     make([]CatapultClient, shardsCount),
+	}
+
+	for i := range c.shards {
+		shard, err := NewClientWithDispatcherConfig(ctx, cfg, dispatcherCfg)
+		if err != nil {
+			cancelFunc()
+			return nil, err
+		}
+
+		c.shards[i] = shard
+	}
+
+	return c, nil
+}
+
+func (c *ShardedClient) Listen() {
+	for _, shard := range c.shards {
+		go shard.Listen()
+	}
+
+	<-c.ctx.Done()
+}
+
+func (c *ShardedClient) Close() error {
+	for _, shard := range c.shards {
+		if err := shard.Close(); err != nil {
+			return err
+		}
+	}
+
+	c.cancelFunc()
+	return nil
+}
+
+func (c *ShardedClient) Config() *sdk.Config {
+	return c.config
+}
+
+// returns sum of dispatcher metrics of all shards
+func (c *ShardedClient) Metrics() DispatcherMetrics {
+	var m DispatcherMetrics
+	for _, shard := range c.shards {
+		m.add(shard.Metrics())
+	}
+
+	return m
+}
+
+// returns shard which serves passed address
+func (c *ShardedClient) shard(address *sdk.Address) CatapultClient {
+	if address == nil {
+		return c.shards[0]
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(address.Address))
+
+	return c.shards[int(h.Sum32()%uint32(len(c.shards)))]
+}
+
+// splits addresses by serving shards
+func (c *ShardedClient) partition(addresses []*sdk.Address) map[CatapultClient][]*sdk.Address {
+	parts := make(map[CatapultClient][]*sdk.Address)
+	for _, address := range addresses {
+		shard := c.shard(address)
+		parts[shard] = append(parts[shard], address)
+	}
+
+	return parts
+}
+
+func (c *ShardedClient) AddBlockHandlers(handlers ...subscribers.BlockHandler) error {
+	return c.shards[0].AddBlockHandlers(handlers...)
+}
+
+func (c *ShardedClient) AddConfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	return c.shard(address).AddConfirmedAddedHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddUnconfirmedAddedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	return c.shard(address).AddUnconfirmedAddedHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddUnconfirmedRemovedHandlers(address *sdk.Address, handlers ...subscribers.UnconfirmedRemovedHandler) error {
+	return c.shard(address).AddUnconfirmedRemovedHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddPartialAddedHandlers(address *sdk.Address, handlers ...subscribers.PartialAddedHandler) error {
+	return c.shard(address).AddPartialAddedHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddPartialRemovedHandlers(address *sdk.Address, handlers ...subscribers.PartialRemovedHandler) error {
+	return c.shard(address).AddPartialRemovedHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddStatusHandlers(address *sdk.Address, handlers ...subscribers.StatusHandler) error {
+	return c.shard(address).AddStatusHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddCosignatureHandlers(address *sdk.Address, handlers ...subscribers.CosignatureHandler) error {
+	return c.shard(address).AddCosignatureHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddDriveStateHandlers(address *sdk.Address, handlers ...subscribers.DriveStateHandler) error {
+	return c.shard(address).AddDriveStateHandlers(address, handlers...)
+}
+
+func (c *ShardedClient) AddConfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.ConfirmedAddedHandler) error {
+	return c.shard(address).AddConfirmedAddedHandlersWithFilter(address, filter, handlers...)
+}
+
+func (c *ShardedClient) AddUnconfirmedAddedHandlersWithFilter(address *sdk.Address, filter TransactionFilter, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	return c.shard(address).AddUnconfirmedAddedHandlersWithFilter(address, filter, handlers...)
+}
+
+func (c *ShardedClient) OnTransfer(address *sdk.Address, handler TransferHandler) error {
+	return c.shard(address).OnTransfer(address, handler)
+}
+
+func (c *ShardedClient) OnLockFunds(address *sdk.Address, handler LockFundsHandler) error {
+	return c.shard(address).OnLockFunds(address, handler)
+}
+
+func (c *ShardedClient) OnSecretLock(address *sdk.Address, handler SecretLockHandler) error {
+	return c.shard(address).OnSecretLock(address, handler)
+}
+
+func (c *ShardedClient) OnSecretProof(address *sdk.Address, handler SecretProofHandler) error {
+	return c.shard(address).OnSecretProof(address, handler)
+}
+
+func (c *ShardedClient) AddConfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.ConfirmedAddedHandler) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.AddConfirmedAddedHandlersForAddresses(part, handlers...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) AddUnconfirmedAddedHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.UnconfirmedAddedHandler) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.AddUnconfirmedAddedHandlersForAddresses(part, handlers...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) AddStatusHandlersForAddresses(addresses []*sdk.Address, handlers ...subscribers.StatusHandler) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.AddStatusHandlersForAddresses(part, handlers...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) RemoveConfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.RemoveConfirmedAddedAddresses(part...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) RemoveUnconfirmedAddedAddresses(addresses ...*sdk.Address) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.RemoveUnconfirmedAddedAddresses(part...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) RemoveStatusAddresses(addresses ...*sdk.Address) error {
+	for shard, part := range c.partition(addresses) {
+		if err := shard.RemoveStatusAddresses(part...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) RegisterTopic(topic *CustomTopic) error {
+	for _, shard := range c.shards {
+		if err := shard.RegisterTopic(topic); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *ShardedClient) AddTopicHandlers(name string, address *sdk.Address, handlers ...subscribers.CustomHandler) error {
+	return c.shard(address).AddTopicHandlers(name, address, handlers...)
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func TestNewShardedClient_InvalidShardsCount(t *testing.T) {
+	_, err := NewShardedClient(context.Background(), &sdk.Config{}, 0)
+	assert.Equal(t, ErrInvalidShardsCount, err)
+}
+
+func TestShardedClient_partition(t *testing.T) {
+	c := &ShardedClient{
+		shards: []CatapultClient{
+			&CatapultWebsocketClientImpl{UID: "0"},
+			&CatapultWebsocketClientImpl{UID: "1"},
+			&CatapultWebsocketClientImpl{UID: "2"},
+		},
+	}
+
+	addresses := make([]*sdk.Address, 100)
+	for i := range addresses {
+		addresses[i] = &sdk.Address{Address: fmt.Sprintf("address-%d", i)}
+	}
+
+	parts := c.partition(addresses)
+
+	total := 0
+	for shard, part := range parts {
+		total += len(part)
+		for _, address := range part {
+			assert.Equal(t, shard, c.shard(address), "address should be always served by the same shard")
+		}
+	}
+
+	assert.Equal(t, len(addresses), total)
+	assert.Len(t, parts, len(c.shards))
+	assert.Equal(t, c.shards[0], c.shard(nil))
+}
@@ -10,7 +10,9 @@ type (
 
 	ConfirmedAdded interface {
 		AddHandlers(address *sdk.Address, handlers ...ConfirmedAddedHandler) error
+		AddHandlersForAddresses(addresses []*sdk.Address, handlers ...ConfirmedAddedHandler) error
 		RemoveHandlers(address *sdk.Address, handlers ...*ConfirmedAddedHandler) bool
+		RemoveAddress(address *sdk.Address) bool
 		HasHandlers(address *sdk.Address) bool
 		GetHandlers(address *sdk.Address) []*ConfirmedAddedHandler
 		GetAddresses() []string
@@ -19,7 +21,9 @@ type (
 	confirmedAddedSubscription struct {
 		address  *sdk.Address
 		handlers []*ConfirmedAddedHandler
-		resultCh chan bool
+		// removes every handler of address instead of passed ones
+		removeAll bool
+		resultCh  chan bool
 	}
 
 	confirmedAddedImpl struct {
@@ -56,19 +60,37 @@ func (e *confirmedAddedImpl) removeSubscription(s *confirmedAddedSubscription) {
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
+	if s.removeAll {
+		delete(e.subscribers, s.address.Address)
+		s.resultCh <- true
+		return
+	}
+
+	removed := make(map[*ConfirmedAddedHandler]bool, len(s.handlers))
+	for _, h := range s.handlers {
+		removed[h] = true
+	}
+
+	// remaining handlers are collected into new slice, so neither stored nor passed handlers are shifted while iterated
 	itemCount := len(e.subscribers[s.address.Address])
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range e.subscribers[s.address.Address] {
-			if removeHandler == currentHandlers {
-				e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address][:index],
-					e.subscribers[s.address.Address][index+1:]...)
-			}
+	remaining := make([]*ConfirmedAddedHandler, 0, itemCount)
+	for _, h := range e.subscribers[s.address.Address] {
+		if !removed[h] {
+			remaining = append(remaining, h)
 		}
 	}
+	e.subscribers[s.address.Address] = remaining
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *confirmedAddedImpl) handleNewSubscription() {
@@ -100,6 +122,24 @@ func (e *confirmedAddedImpl) AddHandlers(address *sdk.Address, handlers ...Confi
 	return nil
 }
 
+// adds the same handlers to every address under one lock without passing subscriptions through channel
+func (e *confirmedAddedImpl) AddHandlersForAddresses(addresses []*sdk.Address, handlers ...ConfirmedAddedHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	for _, address := range addresses {
+		for i := range handlers {
+			h := handlers[i]
+			e.subscribers[address.Address] = append(e.subscribers[address.Address], &h)
+		}
+	}
+
+	return nil
+}
+
 func (e *confirmedAddedImpl) RemoveHandlers(address *sdk.Address, handlers ...*ConfirmedAddedHandler) bool {
 
 	if len(handlers) == 0 {
@@ -126,12 +166,26 @@ func (e *confirmedAddedImpl) GetHandlers(address *sdk.Address) []*ConfirmedAdded
 	e.Lock()
 	defer e.Unlock()
 	if res, ok := e.subscribers[address.Address]; ok && res != nil {
-		return res
+		// copy, so the caller can't modify handlers or pass them to RemoveHandlers while they are being removed
+		return append(make([]*ConfirmedAddedHandler, 0, len(res)), res...)
 	}
 
 	return nil
 }
 
+// removes every handler of address, returns false when address doesn't have handlers.
+// It is passed through the same channel as AddHandlers, so handlers added before are removed too
+func (e *confirmedAddedImpl) RemoveAddress(address *sdk.Address) bool {
+	resCh := make(chan bool)
+	e.removeSubscriberCh <- &confirmedAddedSubscription{
+		address:   address,
+		removeAll: true,
+		resultCh:  resCh,
+	}
+
+	return <-resCh
+}
+
 func (e *confirmedAddedImpl) GetAddresses() []string {
 	e.Lock()
 	defer e.Unlock()
@@ -94,6 +94,47 @@ func Test_confirmedAddedImpl_AddHandlers(t *testing.T) {
 	}
 }
 
+func Test_confirmedAddedImpl_AddHandlersForAddresses(t *testing.T) {
+	address1 := &sdk.Address{Address: "test-address-1"}
+	address2 := &sdk.Address{Address: "test-address-2"}
+
+	// channels aren't served, so handlers have to be added without them
+	e := &confirmedAddedImpl{
+		subscribers:        make(map[string][]*ConfirmedAddedHandler),
+		newSubscriberCh:    make(chan *confirmedAddedSubscription),
+		removeSubscriberCh: make(chan *confirmedAddedSubscription),
+	}
+
+	assert.Nil(t, e.AddHandlersForAddresses([]*sdk.Address{address1, address2}))
+	assert.Empty(t, e.subscribers)
+
+	err := e.AddHandlersForAddresses([]*sdk.Address{address1, address2}, confirmedAddedHandlerFunc1, confirmedAddedHandlerFunc2)
+	assert.Nil(t, err)
+	assert.Len(t, e.GetHandlers(address1), 2)
+	assert.Len(t, e.GetHandlers(address2), 2)
+	assert.ElementsMatch(t, []string{address1.Address, address2.Address}, e.GetAddresses())
+}
+
+func Test_confirmedAddedImpl_RemoveAddress(t *testing.T) {
+	address := &sdk.Address{Address: "test-address"}
+
+	e := NewConfirmedAdded()
+	assert.False(t, e.RemoveAddress(address))
+
+	assert.Nil(t, e.AddHandlers(address, confirmedAddedHandlerFunc1, confirmedAddedHandlerFunc2, confirmedAddedHandlerFunc1))
+
+	// returned handlers are a copy, so removing them doesn't shrink the slice which is being iterated
+	handlers := e.GetHandlers(address)
+	assert.Len(t, handlers, 3)
+	assert.True(t, e.RemoveHandlers(address, handlers...))
+	assert.False(t, e.HasHandlers(address))
+
+	assert.Nil(t, e.AddHandlers(address, confirmedAddedHandlerFunc1, confirmedAddedHandlerFunc2))
+	assert.True(t, e.RemoveAddress(address))
+	assert.False(t, e.HasHandlers(address))
+	assert.Empty(t, e.GetAddresses())
+}
+
 func Test_confirmedAddedImpl_RemoveHandlers(t *testing.T) {
 	type args struct {
 		address  *sdk.Address
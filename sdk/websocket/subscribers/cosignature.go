@@ -56,6 +56,7 @@ func (e *cosignatureImpl) removeSubscription(s *cosignatureSubscription) {
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
 	itemCount := len(e.subscribers[s.address.Address])
@@ -68,7 +69,13 @@ func (e *cosignatureImpl) removeSubscription(s *cosignatureSubscription) {
 		}
 	}
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *cosignatureImpl) handleNewSubscription() {
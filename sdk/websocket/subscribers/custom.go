@@ -0,0 +1,126 @@
+package subscribers
+
+import (
+	"sync"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+type (
+	// CustomHandler receives message of custom topic decoded by topic decoder
+	CustomHandler func(interface{}) bool
+
+	// Custom stores handlers of custom topic. Handlers of topics not bound to address are stored with nil address
+	Custom interface {
+		AddHandlers(address *sdk.Address, handlers ...CustomHandler) error
+		RemoveHandlers(address *sdk.Address, handlers ...*CustomHandler) bool
+		HasHandlers(address *sdk.Address) bool
+		GetHandlers(address *sdk.Address) []*CustomHandler
+		GetAddresses() []string
+	}
+
+	customImpl struct {
+		sync.Mutex
+		subscribers map[string][]*CustomHandler
+	}
+)
+
+func NewCustom() Custom {
+	return &customImpl{
+		subscribers: make(map[string][]*CustomHandler),
+	}
+}
+
+func customKey(address *sdk.Address) string {
+	if address == nil {
+		return ""
+	}
+
+	return address.Address
+}
+
+func (e *customImpl) AddHandlers(address *sdk.Address, handlers ...CustomHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	key := customKey(address)
+	for i := range handlers {
+		e.subscribers[key] = append(e.subscribers[key], &handlers[i])
+	}
+
+	return nil
+}
+
+func (e *customImpl) RemoveHandlers(address *sdk.Address, handlers ...*CustomHandler) bool {
+	if len(handlers) == 0 {
+		return false
+	}
+
+	e.Lock()
+	defer e.Unlock()
+
+	key := customKey(address)
+	current, ok := e.subscribers[key]
+	if !ok {
+		return false
+	}
+
+	remain := make([]*CustomHandler, 0, len(current))
+	for _, h := range current {
+		removed := false
+		for _, removeHandler := range handlers {
+			if h == removeHandler {
+				removed = true
+				break
+			}
+		}
+
+		if !removed {
+			remain = append(remain, h)
+		}
+	}
+
+	if len(remain) == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, key)
+	} else {
+		e.subscribers[key] = remain
+	}
+
+	return len(remain) != len(current)
+}
+
+func (e *customImpl) HasHandlers(address *sdk.Address) bool {
+	e.Lock()
+	defer e.Unlock()
+	return len(e.subscribers[customKey(address)]) > 0
+}
+
+func (e *customImpl) GetHandlers(address *sdk.Address) []*CustomHandler {
+	e.Lock()
+	defer e.Unlock()
+	if res, ok := e.subscribers[customKey(address)]; ok {
+		return append([]*CustomHandler(nil), res...)
+	}
+
+	return nil
+}
+
+func (e *customImpl) GetAddresses() []string {
+	e.Lock()
+	defer e.Unlock()
+	addresses := make([]string, 0, len(e.subscribers))
+	for address := range e.subscribers {
+		if address == "" {
+			continue
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
@@ -0,0 +1,34 @@
+package subscribers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+func Test_customImpl_Handlers(t *testing.T) {
+	address := &sdk.Address{Address: "test-address"}
+	handler1 := CustomHandler(func(interface{}) bool { return false })
+	handler2 := CustomHandler(func(interface{}) bool { return true })
+
+	s := NewCustom()
+	assert.Nil(t, s.AddHandlers(address, handler1, handler2))
+	assert.Nil(t, s.AddHandlers(nil, handler1))
+
+	assert.True(t, s.HasHandlers(address))
+	assert.True(t, s.HasHandlers(nil))
+	assert.Equal(t, []string{"test-address"}, s.GetAddresses())
+
+	handlers := s.GetHandlers(address)
+	assert.Len(t, handlers, 2)
+
+	assert.True(t, s.RemoveHandlers(address, handlers[0]))
+	assert.False(t, s.RemoveHandlers(address, handlers[0]))
+	assert.True(t, s.HasHandlers(address))
+
+	assert.True(t, s.RemoveHandlers(address, handlers[1]))
+	assert.False(t, s.HasHandlers(address))
+	assert.Empty(t, s.GetAddresses())
+}
@@ -66,6 +66,7 @@ func (e *partialAddedImpl) removeSubscription(s *partialAddedSubscription) {
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
 	itemCount := len(e.subscribers[s.address.Address])
@@ -78,7 +79,13 @@ func (e *partialAddedImpl) removeSubscription(s *partialAddedSubscription) {
 		}
 	}
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *partialAddedImpl) AddHandlers(address *sdk.Address, handlers ...PartialAddedHandler) error {
@@ -67,6 +67,7 @@ func (e *partialRemovedImpl) removeSubscription(s *partialRemovedSubscription) {
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
 	itemCount := len(e.subscribers[s.address.Address])
@@ -79,7 +80,13 @@ func (e *partialRemovedImpl) removeSubscription(s *partialRemovedSubscription) {
 		}
 	}
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *partialRemovedImpl) AddHandlers(address *sdk.Address, handlers ...PartialRemovedHandler) error {
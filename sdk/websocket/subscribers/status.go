@@ -10,7 +10,9 @@ type (
 
 	Status interface {
 		AddHandlers(address *sdk.Address, handlers ...StatusHandler) error
+		AddHandlersForAddresses(addresses []*sdk.Address, handlers ...StatusHandler) error
 		RemoveHandlers(address *sdk.Address, handlers ...*StatusHandler) bool
+		RemoveAddress(address *sdk.Address) bool
 		HasHandlers(address *sdk.Address) bool
 		GetHandlers(address *sdk.Address) []*StatusHandler
 		GetAddresses() []string
@@ -25,7 +27,9 @@ type (
 	statusSubscription struct {
 		address  *sdk.Address
 		handlers []*StatusHandler
-		resultCh chan bool
+		// removes every handler of address instead of passed ones
+		removeAll bool
+		resultCh  chan bool
 	}
 )
 
@@ -67,19 +71,37 @@ func (e *statusImpl) removeSubscription(s *statusSubscription) {
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
+	if s.removeAll {
+		delete(e.subscribers, s.address.Address)
+		s.resultCh <- true
+		return
+	}
+
+	removed := make(map[*StatusHandler]bool, len(s.handlers))
+	for _, h := range s.handlers {
+		removed[h] = true
+	}
+
+	// remaining handlers are collected into new slice, so neither stored nor passed handlers are shifted while iterated
 	itemCount := len(e.subscribers[s.address.Address])
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range e.subscribers[s.address.Address] {
-			if removeHandler == currentHandlers {
-				e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address][:index],
-					e.subscribers[s.address.Address][index+1:]...)
-			}
+	remaining := make([]*StatusHandler, 0, itemCount)
+	for _, h := range e.subscribers[s.address.Address] {
+		if !removed[h] {
+			remaining = append(remaining, h)
 		}
 	}
+	e.subscribers[s.address.Address] = remaining
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *statusImpl) AddHandlers(address *sdk.Address, handlers ...StatusHandler) error {
@@ -100,6 +122,24 @@ func (e *statusImpl) AddHandlers(address *sdk.Address, handlers ...StatusHandler
 	return nil
 }
 
+// adds the same handlers to every address under one lock without passing subscriptions through channel
+func (e *statusImpl) AddHandlersForAddresses(addresses []*sdk.Address, handlers ...StatusHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	for _, address := range addresses {
+		for i := range handlers {
+			h := handlers[i]
+			e.subscribers[address.Address] = append(e.subscribers[address.Address], &h)
+		}
+	}
+
+	return nil
+}
+
 func (e *statusImpl) RemoveHandlers(address *sdk.Address, handlers ...*StatusHandler) bool {
 	if len(handlers) == 0 {
 		return false
@@ -125,12 +165,26 @@ func (e *statusImpl) GetHandlers(address *sdk.Address) []*StatusHandler {
 	e.Lock()
 	defer e.Unlock()
 	if res, ok := e.subscribers[address.Address]; ok && res != nil {
-		return res
+		// copy, so the caller can't modify handlers or pass them to RemoveHandlers while they are being removed
+		return append(make([]*StatusHandler, 0, len(res)), res...)
 	}
 
 	return nil
 }
 
+// removes every handler of address, returns false when address doesn't have handlers.
+// It is passed through the same channel as AddHandlers, so handlers added before are removed too
+func (e *statusImpl) RemoveAddress(address *sdk.Address) bool {
+	resCh := make(chan bool)
+	e.removeSubscriberCh <- &statusSubscription{
+		address:   address,
+		removeAll: true,
+		resultCh:  resCh,
+	}
+
+	return <-resCh
+}
+
 func (e *statusImpl) GetAddresses() []string {
 	e.Lock()
 	defer e.Unlock()
@@ -9,7 +9,9 @@ type (
 	UnconfirmedAddedHandler func(sdk.Transaction) bool
 	UnconfirmedAdded        interface {
 		AddHandlers(address *sdk.Address, handlers ...UnconfirmedAddedHandler) error
+		AddHandlersForAddresses(addresses []*sdk.Address, handlers ...UnconfirmedAddedHandler) error
 		RemoveHandlers(address *sdk.Address, handlers ...*UnconfirmedAddedHandler) bool
+		RemoveAddress(address *sdk.Address) bool
 		HasHandlers(address *sdk.Address) bool
 		GetHandlers(address *sdk.Address) []*UnconfirmedAddedHandler
 		GetAddresses() []string
@@ -24,7 +26,9 @@ type (
 	unconfirmedAddedSubscription struct {
 		address  *sdk.Address
 		handlers []*UnconfirmedAddedHandler
-		resultCh chan bool
+		// removes every handler of address instead of passed ones
+		removeAll bool
+		resultCh  chan bool
 	}
 )
 
@@ -66,19 +70,37 @@ func (e *unconfirmedAddedImpl) removeSubscription(s *unconfirmedAddedSubscriptio
 	defer e.Unlock()
 	if external, ok := e.subscribers[s.address.Address]; !ok || len(external) == 0 {
 		s.resultCh <- false
+		return
 	}
 
+	if s.removeAll {
+		delete(e.subscribers, s.address.Address)
+		s.resultCh <- true
+		return
+	}
+
+	removed := make(map[*UnconfirmedAddedHandler]bool, len(s.handlers))
+	for _, h := range s.handlers {
+		removed[h] = true
+	}
+
+	// remaining handlers are collected into new slice, so neither stored nor passed handlers are shifted while iterated
 	itemCount := len(e.subscribers[s.address.Address])
-	for _, removeHandler := range s.handlers {
-		for index, currentHandlers := range e.subscribers[s.address.Address] {
-			if removeHandler == currentHandlers {
-				e.subscribers[s.address.Address] = append(e.subscribers[s.address.Address][:index],
-					e.subscribers[s.address.Address][index+1:]...)
-			}
+	remaining := make([]*UnconfirmedAddedHandler, 0, itemCount)
+	for _, h := range e.subscribers[s.address.Address] {
+		if !removed[h] {
+			remaining = append(remaining, h)
 		}
 	}
+	e.subscribers[s.address.Address] = remaining
 
-	s.resultCh <- itemCount != len(e.subscribers[s.address.Address])
+	remainCount := len(e.subscribers[s.address.Address])
+	if remainCount == 0 {
+		// drop address, so it is not resubscribed after reconnect
+		delete(e.subscribers, s.address.Address)
+	}
+
+	s.resultCh <- itemCount != remainCount
 }
 
 func (e *unconfirmedAddedImpl) AddHandlers(address *sdk.Address, handlers ...UnconfirmedAddedHandler) error {
@@ -99,6 +121,24 @@ func (e *unconfirmedAddedImpl) AddHandlers(address *sdk.Address, handlers ...Unc
 	return nil
 }
 
+// adds the same handlers to every address under one lock without passing subscriptions through channel
+func (e *unconfirmedAddedImpl) AddHandlersForAddresses(addresses []*sdk.Address, handlers ...UnconfirmedAddedHandler) error {
+	if len(handlers) == 0 {
+		return nil
+	}
+
+	e.Lock()
+	defer e.Unlock()
+	for _, address := range addresses {
+		for i := range handlers {
+			h := handlers[i]
+			e.subscribers[address.Address] = append(e.subscribers[address.Address], &h)
+		}
+	}
+
+	return nil
+}
+
 func (e *unconfirmedAddedImpl) RemoveHandlers(address *sdk.Address, handlers ...*UnconfirmedAddedHandler) bool {
 	if len(handlers) == 0 {
 		return false
@@ -124,12 +164,26 @@ func (e *unconfirmedAddedImpl) GetHandlers(address *sdk.Address) []*UnconfirmedA
 	e.Lock()
 	defer e.Unlock()
 	if res, ok := e.subscribers[address.Address]; ok && res != nil {
-		return res
+		// copy, so the caller can't modify handlers or pass them to RemoveHandlers while they are being removed
+		return append(make([]*UnconfirmedAddedHandler, 0, len(res)), res...)
 	}
 
 	return nil
 }
 
+// removes every handler of address, returns false when address doesn't have handlers.
+// It is passed through the same channel as AddHandlers, so handlers added before are removed too
+func (e *unconfirmedAddedImpl) RemoveAddress(address *sdk.Address) bool {
+	resCh := make(chan bool)
+	e.removeSubscriberCh <- &unconfirmedAddedSubscription{
+		address:   address,
+		removeAll: true,
+		resultCh:  resCh,
+	}
+
+	return <-resCh
+}
+
 func (e *unconfirmedAddedImpl) GetAddresses() []string {
 	e.Lock()
 	defer e.Unlock()
@@ -95,7 +95,8 @@ func (dto *WsMessageInfoDTO) ToStruct() (*WsMessageInfo, error) {
 		ChannelName: dto.Meta.ChannelName,
 	}
 
-	if dto.Meta.ChannelName == "block" {
+	// block and custom topics not bound to address have no address in meta
+	if dto.Meta.ChannelName == "block" || dto.Meta.Address == "" {
 		return msg, nil
 	}
 
@@ -0,0 +1,36 @@
+// Copyright 2019 ProximaX Limited. All rights reserved.
+// Use of this source code is governed by the Apache 2.0
+// license that can be found in the LICENSE file.
+package integration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/proximax-storage/go-xpx-chain-sdk/sdk"
+)
+
+// checks header layout against blocks produced by real node
+func TestVerifyBlockHeaders_NodeBlocks(t *testing.T) {
+	height, err := client.Blockchain.GetBlockchainHeight(ctx)
+	assert.Nil(t, err)
+
+	from := sdk.Height(2)
+	if height > 25 {
+		from = height - 25
+	}
+
+	blocks, err := client.Blockchain.GetBlocksByHeightWithLimit(ctx, from, 25)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, blocks)
+
+	for _, b := range blocks {
+		hash, err := b.CalculateHash()
+		assert.Nil(t, err)
+		assert.Equal(t, b.BlockHash, hash, "hash of block at height %d", b.Height)
+		assert.Nil(t, sdk.VerifyBlockHeader(b), "signature of block at height %d", b.Height)
+	}
+
+	assert.Nil(t, sdk.VerifyBlockHeaders(blocks...))
+}